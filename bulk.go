@@ -0,0 +1,220 @@
+package mt5api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// OrderFilter selects orders for bulk operations
+type OrderFilter func(order Order) bool
+
+// BySymbol selects orders on a symbol
+func BySymbol(symbol string) OrderFilter {
+	return func(order Order) bool { return order.Symbol == symbol }
+}
+
+// ByExpertId selects orders placed by an expert
+func ByExpertId(expertId int64) OrderFilter {
+	return func(order Order) bool { return order.ExpertId == expertId }
+}
+
+// ByComment selects orders whose comment contains s
+func ByComment(s string) OrderFilter {
+	return func(order Order) bool { return strings.Contains(order.Comment, s) }
+}
+
+// Positions selects open market positions
+func Positions(order Order) bool {
+	return isMarketOrder(order.OrderType)
+}
+
+// PendingOrders selects pending orders
+func PendingOrders(order Order) bool {
+	switch order.OrderType {
+	case OrderBuyLimit, OrderSellLimit, OrderBuyStop, OrderSellStop, OrderBuyStopLimit, OrderSellStopLimit:
+		return true
+	}
+	return false
+}
+
+// Losers selects positions with a negative net profit, including swap,
+// commission and fees
+func Losers(order Order) bool {
+	return Positions(order) && netProfit(order) < 0
+}
+
+// Winners selects positions with a positive net profit, including swap,
+// commission and fees
+func Winners(order Order) bool {
+	return Positions(order) && netProfit(order) > 0
+}
+
+// BulkOptions configures bulk close operations
+type BulkOptions struct {
+	Concurrency int // Maximum parallel OrderClose calls, default 8
+
+	// Price returns the close price of an order; nil or zero closes at market
+	Price func(order Order) float64
+	// Slippage returns the deviation in points of an order; nil or zero uses
+	// the server default
+	Slippage func(order Order) int64
+	// Execution resubmits closes on requotes with ExecuteClose when set
+	Execution *ExecutionOptions
+}
+
+// CloseResult holds the outcome of closing one order
+type CloseResult struct {
+	Ticket int64
+	Symbol string
+	Order  *Order // The closed order, nil on failure
+	Err    error
+}
+
+// BulkReport holds the per-ticket results of a bulk operation, sorted by
+// ticket
+type BulkReport struct {
+	Results []CloseResult
+}
+
+// Failed returns the results that failed
+func (r *BulkReport) Failed() []CloseResult {
+	var failed []CloseResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of the report, annotated with the ticket
+func (r *BulkReport) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("ticket %d: %w", result.Ticket, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// CloseOrders closes every open order matching all filters. Orders are
+// closed concurrently and failures do not stop the others; the error is
+// only set when the open orders cannot be listed.
+func (c *Client) CloseOrders(ctx context.Context, opts BulkOptions, filters ...OrderFilter) (*BulkReport, error) {
+	orders, err := c.OpenedOrders(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := slices.DeleteFunc(orders, func(order Order) bool {
+		for _, filter := range filters {
+			if !filter(order) {
+				return true
+			}
+		}
+		return false
+	})
+	return c.closeOrders(ctx, selected, opts), nil
+}
+
+// CloseAll closes all open positions
+func (c *Client) CloseAll(ctx context.Context, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Positions)
+}
+
+// CloseSymbol closes all open positions on a symbol
+func (c *Client) CloseSymbol(ctx context.Context, symbol string, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Positions, BySymbol(symbol))
+}
+
+// CloseByExpert closes all open positions placed by an expert
+func (c *Client) CloseByExpert(ctx context.Context, expertId int64, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Positions, ByExpertId(expertId))
+}
+
+// CloseByComment closes all open positions whose comment contains s
+func (c *Client) CloseByComment(ctx context.Context, s string, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Positions, ByComment(s))
+}
+
+// CloseLosers closes all positions with a negative net profit
+func (c *Client) CloseLosers(ctx context.Context, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Losers)
+}
+
+// CloseWinners closes all positions with a positive net profit
+func (c *Client) CloseWinners(ctx context.Context, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, Winners)
+}
+
+// CancelPending cancels all pending orders
+func (c *Client) CancelPending(ctx context.Context, opts BulkOptions) (*BulkReport, error) {
+	return c.CloseOrders(ctx, opts, PendingOrders)
+}
+
+// closeOrders closes orders concurrently, bounded by opts.Concurrency
+func (c *Client) closeOrders(ctx context.Context, orders []Order, opts BulkOptions) *BulkReport {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]CloseResult, len(orders))
+		sem     = make(chan struct{}, concurrency)
+	)
+	for i, order := range orders {
+		results[i] = CloseResult{Ticket: order.Ticket, Symbol: order.Symbol}
+
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Order, results[i].Err = c.closeOrder(ctx, order, opts)
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b CloseResult) int {
+		return cmp.Compare(a.Ticket, b.Ticket)
+	})
+	return &BulkReport{Results: results}
+}
+
+// closeOrder closes one order with the bulk options
+func (c *Client) closeOrder(ctx context.Context, order Order, opts BulkOptions) (*Order, error) {
+	req := OrderCloseRequest{Ticket: order.Ticket}
+	if opts.Price != nil {
+		req.Price = opts.Price(order)
+	}
+	if opts.Slippage != nil {
+		req.Slippage = opts.Slippage(order)
+	}
+
+	if opts.Execution == nil || !isMarketOrder(order.OrderType) {
+		return c.OrderClose(ctx, req)
+	}
+	report, err := c.ExecuteClose(ctx, req, *opts.Execution)
+	if err != nil {
+		return nil, err
+	}
+	return report.Order, nil
+}
+
+// netProfit returns the profit of an order including swap, commission and
+// fees
+func netProfit(order Order) float64 {
+	return order.Profit + order.Swap + order.Commission + order.Fee
+}
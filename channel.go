@@ -0,0 +1,272 @@
+package mt5api
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what happens when a stream buffer is full
+type OverflowPolicy string
+
+const (
+	OverflowBlock      OverflowPolicy = "Block"      // Wait for the consumer, stalling the socket
+	OverflowDropOldest OverflowPolicy = "DropOldest" // Discard the oldest buffered message
+	OverflowDropNewest OverflowPolicy = "DropNewest" // Discard the incoming message
+	OverflowConflate   OverflowPolicy = "Conflate"   // Keep only the latest message per key
+)
+
+// StreamStats counts messages passing through a channel stream
+type StreamStats struct {
+	delivered atomic.Int64
+	dropped   atomic.Int64
+}
+
+// Delivered returns the number of messages received by the consumer
+func (s *StreamStats) Delivered() int64 {
+	return s.delivered.Load()
+}
+
+// Dropped returns the number of messages discarded or conflated
+func (s *StreamStats) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// StreamOptions configures a channel stream
+type StreamOptions[T any] struct {
+	Buffer    int            // Buffered messages, default 256
+	Overflow  OverflowPolicy // Default OverflowBlock
+	Key       func(T) string // Conflation key, required for OverflowConflate
+	Stats     *StreamStats   // Optional counters
+	Subscribe SubscribeOptions
+}
+
+// Stream subscribes to a WebSocket endpoint and delivers decoded messages
+// on a channel. Errors are delivered without blocking and dropped when the
+// error channel is full. Both channels are closed when ctx is done.
+func Stream[T any](ctx context.Context, c *Client, endpoint string, decode Decoder[T], opts StreamOptions[T]) (<-chan T, <-chan error) {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 256
+	}
+	if opts.Overflow == "" || (opts.Overflow == OverflowConflate && opts.Key == nil) {
+		opts.Overflow = OverflowBlock
+	}
+	if opts.Stats == nil {
+		opts.Stats = &StreamStats{}
+	}
+
+	out := make(chan T)
+	errs := make(chan error, 16)
+	queue := newStreamQueue(opts)
+	// Wake a producer blocked on a full buffer when ctx is done
+	context.AfterFunc(ctx, queue.close)
+
+	subscribeOpts := opts.Subscribe
+	onError := subscribeOpts.OnError
+	subscribeOpts.OnError = func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	subscribed := make(chan struct{})
+	go func() {
+		defer close(subscribed)
+		Subscribe(ctx, c, endpoint, decode, func(value T) { queue.push(ctx, value) }, subscribeOpts)
+		queue.close()
+	}()
+
+	go func() {
+		defer func() {
+			// Errors may be reported until the subscription has stopped
+			<-subscribed
+			close(errs)
+			close(out)
+		}()
+		for {
+			value, ok := queue.pop()
+			if !ok {
+				return
+			}
+			select {
+			case out <- value:
+				opts.Stats.delivered.Add(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errs
+}
+
+// QuoteStream delivers quotes on a channel, by default conflated per symbol
+// so the consumer always sees the latest price
+func (c *Client) QuoteStream(ctx context.Context, opts StreamOptions[Quote]) (<-chan Quote, <-chan error) {
+	if opts.Overflow == "" {
+		opts.Overflow = OverflowConflate
+	}
+	if opts.Key == nil {
+		opts.Key = func(q Quote) string { return q.Symbol }
+	}
+	return Stream(ctx, c, "/OnQuote", quoteDecoder, opts)
+}
+
+// OrderUpdateStream delivers order updates on a channel
+func (c *Client) OrderUpdateStream(ctx context.Context, opts StreamOptions[OrderUpdateSummary]) (<-chan OrderUpdateSummary, <-chan error) {
+	return Stream(ctx, c, "/OnOrderUpdate", orderUpdateDecoder, opts)
+}
+
+// OrderProfitStream delivers profit updates on a channel
+func (c *Client) OrderProfitStream(ctx context.Context, opts StreamOptions[ProfitUpdate]) (<-chan ProfitUpdate, <-chan error) {
+	return Stream(ctx, c, "/OnOrderProfit", orderProfitDecoder, opts)
+}
+
+// OHLCStream delivers OHLC bars on a channel
+func (c *Client) OHLCStream(ctx context.Context, opts StreamOptions[OhlcSubscription]) (<-chan OhlcSubscription, <-chan error) {
+	return Stream(ctx, c, "/OnOhlc", ohlcDecoder, opts)
+}
+
+// MarketWatchStream delivers market watch data on a channel
+func (c *Client) MarketWatchStream(ctx context.Context, opts StreamOptions[MarketWatch]) (<-chan MarketWatch, <-chan error) {
+	return Stream(ctx, c, "/OnMarketWatch", marketWatchDecoder, opts)
+}
+
+// OrderBookStream delivers order book snapshots on a channel, by default
+// conflated per symbol
+func (c *Client) OrderBookStream(ctx context.Context, opts StreamOptions[DepthOfMarket]) (<-chan DepthOfMarket, <-chan error) {
+	if opts.Overflow == "" {
+		opts.Overflow = OverflowConflate
+	}
+	if opts.Key == nil {
+		opts.Key = func(d DepthOfMarket) string { return d.Symbol }
+	}
+	return Stream(ctx, c, "/OnOrderBook", orderBookDecoder, opts)
+}
+
+// TickHistoryStream delivers tick history on a channel
+func (c *Client) TickHistoryStream(ctx context.Context, opts StreamOptions[TickHistoryEventArgs]) (<-chan TickHistoryEventArgs, <-chan error) {
+	return Stream(ctx, c, "/OnTickHistory", tickHistoryDecoder, opts)
+}
+
+// TickValueStream delivers tick value updates on a channel
+func (c *Client) TickValueStream(ctx context.Context, opts StreamOptions[SymbolTickValue]) (<-chan SymbolTickValue, <-chan error) {
+	return Stream(ctx, c, "/OnTickValue", tickValueDecoder, opts)
+}
+
+// MailStream delivers mail messages on a channel
+func (c *Client) MailStream(ctx context.Context, opts StreamOptions[MailMessage]) (<-chan MailMessage, <-chan error) {
+	return Stream(ctx, c, "/OnMail", mailDecoder, opts)
+}
+
+// streamQueue buffers messages between the socket and the consumer
+type streamQueue[T any] struct {
+	opts StreamOptions[T]
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	items  []T
+	keys   []string     // Conflation keys in arrival order
+	latest map[string]T // Latest message per conflation key
+	closed bool
+}
+
+// newStreamQueue creates a queue for the overflow policy
+func newStreamQueue[T any](opts StreamOptions[T]) *streamQueue[T] {
+	q := &streamQueue[T]{opts: opts}
+	q.cond = sync.NewCond(&q.mu)
+	if opts.Overflow == OverflowConflate {
+		q.latest = make(map[string]T)
+	}
+	return q
+}
+
+// len returns the number of buffered messages; callers must hold q.mu
+func (q *streamQueue[T]) len() int {
+	if q.latest != nil {
+		return len(q.keys)
+	}
+	return len(q.items)
+}
+
+// push adds a message according to the overflow policy
+func (q *streamQueue[T]) push(ctx context.Context, value T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch q.opts.Overflow {
+	case OverflowConflate:
+		key := q.opts.Key(value)
+		if _, ok := q.latest[key]; ok {
+			q.opts.Stats.dropped.Add(1)
+		} else {
+			if len(q.keys) >= q.opts.Buffer {
+				// Too many distinct keys; evict the oldest one
+				delete(q.latest, q.keys[0])
+				q.keys = q.keys[1:]
+				q.opts.Stats.dropped.Add(1)
+			}
+			q.keys = append(q.keys, key)
+		}
+		q.latest[key] = value
+	case OverflowDropOldest:
+		if len(q.items) >= q.opts.Buffer {
+			q.items = q.items[1:]
+			q.opts.Stats.dropped.Add(1)
+		}
+		q.items = append(q.items, value)
+	case OverflowDropNewest:
+		if len(q.items) >= q.opts.Buffer {
+			q.opts.Stats.dropped.Add(1)
+			return
+		}
+		q.items = append(q.items, value)
+	default:
+		for len(q.items) >= q.opts.Buffer && !q.closed && ctx.Err() == nil {
+			q.cond.Wait()
+		}
+		if q.closed || ctx.Err() != nil {
+			return
+		}
+		q.items = append(q.items, value)
+	}
+	q.cond.Broadcast()
+}
+
+// pop removes the next message, waiting until one is available; ok is false
+// once the queue is closed and drained
+func (q *streamQueue[T]) pop() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.len() == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.len() == 0 {
+		return value, false
+	}
+
+	if q.latest != nil {
+		key := q.keys[0]
+		q.keys = q.keys[1:]
+		value = q.latest[key]
+		delete(q.latest, key)
+	} else {
+		value = q.items[0]
+		q.items = q.items[1:]
+	}
+	q.cond.Broadcast()
+	return value, true
+}
+
+// close wakes all waiters and stops accepting messages
+func (q *streamQueue[T]) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
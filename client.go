@@ -3,20 +3,59 @@ package mt5api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
-// Client represents the MT5 API client
+// Client represents the MT5 API client. A Client is safe for concurrent use
+// once its exported configuration fields are set.
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
-	Token      string // Session token from Connect
-	Timezone   int
+
+	// RetryPolicy is applied to idempotent requests; nil disables retries.
+	// Order and connection calls are only retried with WithTradingRetry.
+	RetryPolicy *RetryPolicy
+
+	// Limiter throttles outgoing requests; nil disables rate limiting
+	Limiter *Limiter
+
+	// Interceptors wrap every HTTP attempt; the first one is outermost
+	Interceptors []Interceptor
+
+	// Logger logs every call with secrets redacted; nil disables logging.
+	// Successful calls are logged at LogLevel and failures at ErrorLogLevel.
+	Logger        *slog.Logger
+	LogLevel      slog.Level
+	ErrorLogLevel slog.Level
+
+	// Metrics receives request and stream activity; nil disables metrics
+	Metrics MetricsCollector
+
+	// SubscribeOptions configures the SocketOn* stream helpers
+	SubscribeOptions SubscribeOptions
+
+	// Registry caches symbol metadata for order helpers; nil fetches
+	// SymbolParams on every use
+	Registry *SymbolRegistry
+
+	// Validator checks orders before OrderSend sends them; nil disables
+	// pre-trade validation
+	Validator OrderValidator
+
+	mu       sync.RWMutex // guards session state below
+	token    string       // Session token from Connect
+	timezone int
+	session  *SessionManager
+
+	subscriptions subscriptionTracker
 }
 
 // NewClient creates a new MT5 API client
@@ -26,41 +65,157 @@ func NewClient(baseURL string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		RetryPolicy:   DefaultRetryPolicy(),
+		LogLevel:      slog.LevelDebug,
+		ErrorLogLevel: slog.LevelWarn,
 	}
 }
 
+// Token returns the session token for authenticated requests
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
 // SetToken sets the session token for authenticated requests
 func (c *Client) SetToken(token string) {
-	c.Token = token
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
 }
 
+// Timezone returns the server timezone offset in hours
+func (c *Client) Timezone() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.timezone
+}
+
+// SetTimezone sets the server timezone offset in hours
 func (c *Client) SetTimezone(timezone int) {
-	c.Timezone = timezone
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timezone = timezone
+}
+
+// sessionManager returns the attached session manager, if any
+func (c *Client) sessionManager() *SessionManager {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
 }
 
-// doRequest performs HTTP request with common error handling
+// doRequest performs HTTP request with common error handling, retries and
+// session recovery
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
-	// Add token to params if available and not already present
-	if c.Token != "" && params.Get("id") == "" {
-		params.Set("id", c.Token)
+	token := c.Token()
+	body, err := c.doWithRetry(ctx, method, endpoint, params)
+	session := c.sessionManager()
+	if err == nil || session == nil || !session.shouldRecover(ctx, endpoint, err) {
+		return body, err
+	}
+
+	if reconnectErr := session.recover(ctx, token); reconnectErr != nil {
+		return nil, fmt.Errorf("%w (reconnect failed: %v)", err, reconnectErr)
+	}
+	// The broker may have taken a trading call before the session was lost
+	if nonIdempotentEndpoints[endpoint] && !tradingRetryAllowed(ctx) {
+		return nil, err
+	}
+	return c.doWithRetry(ctx, method, endpoint, params)
+}
+
+// doWithRetry performs HTTP request applying the retry policy
+func (c *Client) doWithRetry(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
+	if !c.canRetry(ctx, endpoint) {
+		return c.doAttempt(ctx, method, endpoint, params)
 	}
 
+	policy := c.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		body, err := c.doAttempt(ctx, method, endpoint, params)
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryable(err) {
+			return body, err
+		}
+		if err := sleepContext(ctx, policy.backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doAttempt performs a single HTTP request through the interceptor chain
+func (c *Client) doAttempt(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
+	// Add token to a copy of params if available and not already present,
+	// so a retried request picks up a refreshed token
+	query := make(url.Values, len(params)+1)
+	for key, values := range params {
+		query[key] = values
+	}
+	if token := c.Token(); token != "" && params.Get("id") == "" {
+		query.Set("id", token)
+	}
+
+	call := &Call{
+		Method:   method,
+		Endpoint: endpoint,
+		Params:   query,
+		Header:   http.Header{},
+	}
+	call.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if c.Limiter != nil {
+		release, err := c.Limiter.Wait(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for rate limiter: %w", err)
+		}
+		defer release()
+	}
+
+	interceptors := c.Interceptors
+	if c.Metrics != nil {
+		interceptors = append([]Interceptor{c.metricsInterceptor}, interceptors...)
+	}
+	if c.Logger != nil {
+		interceptors = append([]Interceptor{c.logInterceptor}, interceptors...)
+	}
+
+	result, err := chainInterceptors(c.send, interceptors)(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("interceptor returned no result for %s", endpoint)
+	}
+	return result.Body, nil
+}
+
+// send executes a call over HTTP; it is the innermost RoundTrip
+func (c *Client) send(ctx context.Context, call *Call) (*CallResult, error) {
 	var reqURL string
-	if method == "GET" && len(params) > 0 {
-		reqURL = fmt.Sprintf("%s%s?%s", c.BaseURL, endpoint, params.Encode())
+	if call.Method == "GET" && len(call.Params) > 0 {
+		reqURL = fmt.Sprintf("%s%s?%s", c.BaseURL, call.Endpoint, call.Params.Encode())
 	} else {
-		reqURL = fmt.Sprintf("%s%s", c.BaseURL, endpoint)
+		reqURL = fmt.Sprintf("%s%s", c.BaseURL, call.Endpoint)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, call.Method, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for key, values := range call.Header {
+		req.Header[key] = values
+	}
 
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
+		// The URL carries credentials and the session token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactURL(urlErr.URL)
+		}
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -70,18 +225,38 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, params
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
+	result := &CallResult{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Latency:    time.Since(start),
+	}
+
 	// Handle API errors (status code 201 indicates exception)
 	if resp.StatusCode == 201 {
-		var apiErr ExceptionResult
-		if err := json.Unmarshal(body, &apiErr); err == nil {
-			return nil, fmt.Errorf("API error [%s]: %s", apiErr.Code, apiErr.Message)
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Endpoint:   call.Endpoint,
+			Params:     redactParams(call.Params),
+		}
+		var exception ExceptionResult
+		if err := json.Unmarshal(body, &exception); err == nil {
+			apiErr.Code = exception.Code
+			apiErr.Message = exception.Message
+			apiErr.StackTrace = exception.StackTrace
+		} else {
+			apiErr.Message = string(body)
 		}
-		return nil, fmt.Errorf("API error: %s", string(body))
+		return result, apiErr
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+		return result, &APIError{
+			Message:    string(body),
+			StatusCode: resp.StatusCode,
+			Endpoint:   call.Endpoint,
+			Params:     redactParams(call.Params),
+		}
 	}
 
-	return body, nil
+	return result, nil
 }
@@ -190,6 +190,6 @@ func (c *Client) Disconnect(ctx context.Context) (string, error) {
 	}
 
 	// Clear token on successful disconnect
-	c.Token = ""
+	c.SetToken("")
 	return string(body), nil
 }
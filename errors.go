@@ -0,0 +1,128 @@
+package mt5api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Sentinel errors for common API error codes, usable with errors.Is
+var (
+	ErrNotConnected  = errors.New("mt5api: not connected")
+	ErrInvalidToken  = errors.New("mt5api: invalid token")
+	ErrMarketClosed  = errors.New("mt5api: market closed")
+	ErrInvalidVolume = errors.New("mt5api: invalid volume")
+	ErrNoMoney       = errors.New("mt5api: not enough money")
+	ErrRequote       = errors.New("mt5api: requote")
+	ErrInvalidStops  = errors.New("mt5api: invalid stops")
+	ErrTimeout       = errors.New("mt5api: timeout")
+	ErrUnknownSymbol = errors.New("mt5api: unknown symbol")
+
+	ErrInvalidExpiration = errors.New("mt5api: invalid expiration")
+	ErrPriceChanged      = errors.New("mt5api: price changed")
+	ErrOffQuotes         = errors.New("mt5api: off quotes")
+)
+
+// codeSentinels maps normalized API error codes to sentinel errors
+var codeSentinels = map[string]error{
+	"NOTCONNECTED":     ErrNotConnected,
+	"CONNECTION":       ErrNotConnected,
+	"CONNECTIONLOST":   ErrNotConnected,
+	"DISCONNECTED":     ErrNotConnected,
+	"INVALIDTOKEN":     ErrInvalidToken,
+	"INVALIDID":        ErrInvalidToken,
+	"TOKENEXPIRED":     ErrInvalidToken,
+	"TOKENNOTFOUND":    ErrInvalidToken,
+	"MARKETCLOSED":     ErrMarketClosed,
+	"INVALIDVOLUME":    ErrInvalidVolume,
+	"NOMONEY":          ErrNoMoney,
+	"NOTENOUGHMONEY":   ErrNoMoney,
+	"REQUOTE":          ErrRequote,
+	"INVALIDSTOPS":     ErrInvalidStops,
+	"TIMEOUT":          ErrTimeout,
+	"TIMEOUTEXCEPTION": ErrTimeout,
+	"INVALIDSYMBOL":    ErrUnknownSymbol,
+	"SYMBOLNOTFOUND":   ErrUnknownSymbol,
+	"UNKNOWNSYMBOL":    ErrUnknownSymbol,
+
+	"INVALIDEXPIRATION": ErrInvalidExpiration,
+	"PRICECHANGED":      ErrPriceChanged,
+	"PRICEOFF":          ErrOffQuotes,
+	"OFFQUOTES":         ErrOffQuotes,
+	"NOQUOTES":          ErrOffQuotes,
+}
+
+// redactedParams lists request parameters never exposed in errors
+var redactedParams = map[string]bool{
+	"id":            true,
+	"token":         true,
+	"password":      true,
+	"proxyPassword": true,
+	"otp":           true,
+}
+
+// APIError represents an error returned by the MT5 API
+type APIError struct {
+	Code       string
+	Message    string
+	StackTrace string
+	StatusCode int
+	Endpoint   string
+	Params     url.Values // Request parameters with secrets redacted
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Code == "" && e.StatusCode != 201 {
+		return fmt.Sprintf("HTTP error %d: %s", e.StatusCode, e.Message)
+	}
+	if e.Code == "" {
+		return fmt.Sprintf("API error: %s", e.Message)
+	}
+	return fmt.Sprintf("API error [%s]: %s", e.Code, e.Message)
+}
+
+// Is reports whether the error code matches the target sentinel error
+func (e *APIError) Is(target error) bool {
+	sentinel := codeSentinel(e.Code)
+	return sentinel != nil && sentinel == target
+}
+
+// codeSentinel returns the sentinel error for an API error code
+func codeSentinel(code string) error {
+	return codeSentinels[normalizeName(strings.TrimPrefix(strings.ToUpper(code), "TRADE_RETCODE_"))]
+}
+
+// normalizeName uppercases s and strips everything but letters and digits
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(s))
+}
+
+// redactURL returns rawURL with secret query parameters replaced
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "REDACTED"
+	}
+	u.RawQuery = redactParams(u.Query()).Encode()
+	return u.String()
+}
+
+// redactParams returns a copy of params with secret values replaced
+func redactParams(params url.Values) url.Values {
+	redacted := make(url.Values, len(params))
+	for key, values := range params {
+		if redactedParams[key] {
+			redacted[key] = []string{"REDACTED"}
+			continue
+		}
+		redacted[key] = append([]string(nil), values...)
+	}
+	return redacted
+}
@@ -0,0 +1,147 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ExecutionOptions configures ExecuteOrder and ExecuteClose
+type ExecutionOptions struct {
+	// MaxAttempts limits submissions (default 3)
+	MaxAttempts int
+	// MaxSlippage is the budget in points the price may move from the first
+	// attempt; zero accepts any price
+	MaxSlippage int64
+	// RetryDelay is the wait before refreshing the price (default 100ms)
+	RetryDelay time.Duration
+}
+
+// ExecutionAttempt reports one submission
+type ExecutionAttempt struct {
+	Price float64
+	Code  string // Error code, empty on success
+	Err   error
+}
+
+// ExecutionReport reports the submissions of ExecuteOrder or ExecuteClose
+type ExecutionReport struct {
+	Order    *Order
+	Attempts []ExecutionAttempt
+}
+
+// ExecuteOrder sends a market order, resubmitting at a refreshed price on
+// requotes, price changes and off quotes while the price stays within the
+// slippage budget. Pending orders are rejected, since re-pricing them
+// would move their trigger price.
+func (c *Client) ExecuteOrder(ctx context.Context, req OrderSendRequest, opts ExecutionOptions) (*ExecutionReport, error) {
+	if !isMarketOrder(req.Operation) {
+		return nil, &ValidationError{
+			Field:   "Operation",
+			Message: fmt.Sprintf("%s is not a market order", req.Operation),
+		}
+	}
+	return c.execute(ctx, req.Symbol, isBuyOrder(req.Operation), req.Price, req.Slippage, opts,
+		func(price float64, slippage int64) (*Order, error) {
+			req.Price = price
+			req.Slippage = slippage
+			return c.OrderSend(ctx, req)
+		})
+}
+
+// ExecuteClose closes an order like OrderClose, resubmitting at a refreshed
+// price on requotes, price changes and off quotes while the price stays
+// within the slippage budget
+func (c *Client) ExecuteClose(ctx context.Context, req OrderCloseRequest, opts ExecutionOptions) (*ExecutionReport, error) {
+	order, err := c.OpenedOrder(ctx, req.Ticket)
+	if err != nil {
+		return nil, err
+	}
+	// Buy positions close at the bid, sell positions at the ask
+	return c.execute(ctx, order.Symbol, !isBuyOrder(order.OrderType), req.Price, req.Slippage, opts,
+		func(price float64, slippage int64) (*Order, error) {
+			req.Price = price
+			req.Slippage = slippage
+			return c.OrderClose(ctx, req)
+		})
+}
+
+// execute runs send until it succeeds, fails with an error that a new price
+// does not fix, or exhausts the attempts or slippage budget. atAsk selects
+// the quote side the trade executes at.
+func (c *Client) execute(ctx context.Context, symbol string, atAsk bool, price float64, slippage int64, opts ExecutionOptions, send func(price float64, slippage int64) (*Order, error)) (*ExecutionReport, error) {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 100 * time.Millisecond
+	}
+
+	params, err := c.symbolParams(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	point := symbolPoint(params.SymbolInfo)
+
+	if price <= 0 {
+		if price, err = c.quotePrice(ctx, symbol, atAsk); err != nil {
+			return nil, err
+		}
+	}
+	reference := price
+
+	report := &ExecutionReport{}
+	for {
+		moved := math.Round(math.Abs(price-reference) / point)
+		// A used up budget stops here, since a zero deviation lets the server
+		// fill at any price
+		if opts.MaxSlippage > 0 && moved >= float64(opts.MaxSlippage) {
+			return report, fmt.Errorf("price %g moved %g points from %g, using up the slippage budget of %d points: %w", price, moved, reference, opts.MaxSlippage, report.Attempts[len(report.Attempts)-1].Err)
+		}
+
+		// The remaining budget bounds the deviation the server may fill at
+		deviation := slippage
+		if remaining := opts.MaxSlippage - int64(moved); opts.MaxSlippage > 0 && (deviation == 0 || deviation > remaining) {
+			deviation = remaining
+		}
+
+		price = NormalizePrice(price, params.SymbolInfo)
+		order, err := send(price, deviation)
+		report.Attempts = append(report.Attempts, ExecutionAttempt{Price: price, Code: errorCode(err), Err: err})
+		if err == nil {
+			report.Order = order
+			return report, nil
+		}
+		if !isPriceError(err) || len(report.Attempts) >= opts.MaxAttempts {
+			return report, err
+		}
+
+		if err := sleepContext(ctx, opts.RetryDelay); err != nil {
+			return report, err
+		}
+		next, quoteErr := c.quotePrice(ctx, symbol, atAsk)
+		if quoteErr != nil {
+			return report, errors.Join(err, quoteErr)
+		}
+		price = next
+	}
+}
+
+// quotePrice returns the current ask or bid of a symbol
+func (c *Client) quotePrice(ctx context.Context, symbol string, ask bool) (float64, error) {
+	quote, err := c.GetQuote(ctx, symbol, 0)
+	if err != nil {
+		return 0, err
+	}
+	if ask {
+		return quote.Ask, nil
+	}
+	return quote.Bid, nil
+}
+
+// isPriceError reports whether err is fixed by resubmitting at a new price
+func isPriceError(err error) bool {
+	return errors.Is(err, ErrRequote) || errors.Is(err, ErrPriceChanged) || errors.Is(err, ErrOffQuotes)
+}
@@ -0,0 +1,114 @@
+package mt5api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expiration modes of SymGroup.Expiration
+const (
+	ExpirationFlagGTC int32 = 1 << iota
+	ExpirationFlagToday
+	ExpirationFlagSpecified
+	ExpirationFlagSpecifiedDay
+)
+
+// expirationFlags maps normalized expiration names to their flag
+var expirationFlags = map[string]int32{
+	"GTC":          ExpirationFlagGTC,
+	"TODAY":        ExpirationFlagToday,
+	"DAY":          ExpirationFlagToday,
+	"SPECIFIED":    ExpirationFlagSpecified,
+	"SPECIFIEDDAY": ExpirationFlagSpecifiedDay,
+}
+
+// ExpirationModes parses SymGroup.Expiration, given either as a bitmask or
+// as a list of names such as "GTC, Today". It returns zero when unknown.
+func ExpirationModes(expiration string) int32 {
+	if mask, err := strconv.ParseInt(strings.TrimSpace(expiration), 10, 32); err == nil {
+		return int32(mask)
+	}
+
+	var mask int32
+	for _, name := range strings.FieldsFunc(expiration, func(r rune) bool {
+		return r == ',' || r == '|' || r == ' '
+	}) {
+		mask |= expirationFlags[strings.TrimPrefix(normalizeName(name), "EXPIRATION")]
+	}
+	return mask
+}
+
+// checkExpiration validates an expiration type and time on their own
+func checkExpiration(expirationType ExpirationType, expiration time.Time) error {
+	switch expirationType {
+	case "":
+		if !expiration.IsZero() {
+			return &ValidationError{Field: "Expiration", Message: "expiration time requires an expiration type", err: ErrInvalidExpiration}
+		}
+	case ExpirationGTC, ExpirationToday:
+		if !expiration.IsZero() {
+			return &ValidationError{Field: "Expiration", Message: fmt.Sprintf("expiration time is not used with %s", expirationType), err: ErrInvalidExpiration}
+		}
+	case ExpirationSpecified, ExpirationSpecifiedDay:
+		if expiration.IsZero() {
+			return &ValidationError{Field: "Expiration", Message: fmt.Sprintf("%s requires an expiration time", expirationType), err: ErrInvalidExpiration}
+		}
+		if expirationType == ExpirationSpecified && !expiration.After(time.Now()) {
+			return &ValidationError{Field: "Expiration", Message: fmt.Sprintf("expiration %s is in the past", expiration.Format(time.RFC3339)), err: ErrInvalidExpiration}
+		}
+	default:
+		return &ValidationError{Field: "ExpirationType", Message: fmt.Sprintf("unknown expiration type %q", expirationType), err: ErrInvalidExpiration}
+	}
+	return nil
+}
+
+// validateExpiration checks an order expiration, and whether the symbol
+// group allows the expiration type when symbol is known
+func (c *Client) validateExpiration(ctx context.Context, symbol string, expirationType ExpirationType, expiration time.Time) error {
+	if err := checkExpiration(expirationType, expiration); err != nil {
+		return err
+	}
+	if expirationType == "" || symbol == "" {
+		return nil
+	}
+
+	params, err := c.symbolParams(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	modes := ExpirationModes(params.SymbolGroup.Expiration)
+	flag := expirationFlags[normalizeName(string(expirationType))]
+	if modes != 0 && modes&flag == 0 {
+		return &ValidationError{
+			Field:   "ExpirationType",
+			Message: fmt.Sprintf("%s does not allow %s expiration (allowed: %s)", symbol, expirationType, params.SymbolGroup.Expiration),
+			err:     ErrInvalidExpiration,
+		}
+	}
+	return nil
+}
+
+// addExpiration adds the expiration parameters, converting the time to the
+// server timezone
+func (c *Client) addExpiration(params url.Values, expirationType ExpirationType, expiration time.Time) {
+	if expirationType != "" {
+		params.Add("expirationType", string(expirationType))
+	}
+	if !expiration.IsZero() {
+		params.Add("expiration", c.serverTime(expiration))
+	}
+}
+
+// serverTime formats t in the server timezone
+func (c *Client) serverTime(t time.Time) string {
+	return c.toServerTime(t).Format("2006-01-02T15:04:05")
+}
+
+// toServerTime returns t as wall clock time of the server timezone
+func (c *Client) toServerTime(t time.Time) time.Time {
+	return t.UTC().Add(time.Duration(c.Timezone()) * time.Hour)
+}
@@ -0,0 +1,92 @@
+package mt5api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckExpiration(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name           string
+		expirationType ExpirationType
+		expiration     time.Time
+		wantErr        bool
+	}{
+		{"none", "", time.Time{}, false},
+		{"time without type", "", future, true},
+		{"GTC", ExpirationGTC, time.Time{}, false},
+		{"GTC with time", ExpirationGTC, future, true},
+		{"today", ExpirationToday, time.Time{}, false},
+		{"today with time", ExpirationToday, future, true},
+		{"specified", ExpirationSpecified, future, false},
+		{"specified without time", ExpirationSpecified, time.Time{}, true},
+		{"specified in the past", ExpirationSpecified, past, true},
+		{"specified day", ExpirationSpecifiedDay, future, false},
+		{"specified day today", ExpirationSpecifiedDay, past, false},
+		{"specified day without time", ExpirationSpecifiedDay, time.Time{}, true},
+		{"unknown type", ExpirationType("Week"), time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExpiration(tt.expirationType, tt.expiration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkExpiration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidExpiration) {
+				t.Errorf("checkExpiration() error = %v, want ErrInvalidExpiration", err)
+			}
+		})
+	}
+}
+
+func TestExpirationModes(t *testing.T) {
+	tests := []struct {
+		expiration string
+		want       int32
+	}{
+		{"", 0},
+		{"15", ExpirationFlagGTC | ExpirationFlagToday | ExpirationFlagSpecified | ExpirationFlagSpecifiedDay},
+		{" 3 ", ExpirationFlagGTC | ExpirationFlagToday},
+		{"GTC", ExpirationFlagGTC},
+		{"GTC, Today", ExpirationFlagGTC | ExpirationFlagToday},
+		{"Day|Specified", ExpirationFlagToday | ExpirationFlagSpecified},
+		{"SPECIFIED_DAY", ExpirationFlagSpecifiedDay},
+		{"ExpirationGTC, ExpirationSpecifiedDay", ExpirationFlagGTC | ExpirationFlagSpecifiedDay},
+		{"Weekly", 0},
+	}
+	for _, tt := range tests {
+		if got := ExpirationModes(tt.expiration); got != tt.want {
+			t.Errorf("ExpirationModes(%q) = %d, want %d", tt.expiration, got, tt.want)
+		}
+	}
+}
+
+func TestServerTime(t *testing.T) {
+	utc := time.Date(2024, 3, 15, 22, 30, 0, 0, time.UTC)
+	bangkok := utc.In(time.FixedZone("ICT", 7*60*60))
+
+	tests := []struct {
+		name     string
+		timezone int
+		t        time.Time
+		want     string
+	}{
+		{"UTC", 0, utc, "2024-03-15T22:30:00"},
+		{"ahead of UTC", 2, utc, "2024-03-16T00:30:00"},
+		{"behind UTC", -5, utc, "2024-03-15T17:30:00"},
+		{"local input", 3, bangkok, "2024-03-16T01:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("http://localhost")
+			c.SetTimezone(tt.timezone)
+			if got := c.serverTime(tt.t); got != tt.want {
+				t.Errorf("serverTime() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
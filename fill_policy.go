@@ -0,0 +1,87 @@
+package mt5api
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fillPolicyNames maps normalized fill policy names and aliases to policies
+var fillPolicyNames = map[string]FillPolicy{
+	"FILLORKILL":         FillFOK,
+	"FOK":                FillFOK,
+	"ORDERFILLINGFOK":    FillFOK,
+	"IMMEDIATEORCANCEL":  FillIOC,
+	"IOC":                FillIOC,
+	"ORDERFILLINGIOC":    FillIOC,
+	"FLASHFILL":          FillReturn,
+	"RETURN":             FillReturn,
+	"ORDERFILLINGRETURN": FillReturn,
+}
+
+// ParseFillPolicy parses a fill policy name such as "FOK", "IOC",
+// "Return" or their MT5 API names
+func ParseFillPolicy(name string) (FillPolicy, bool) {
+	policy, ok := fillPolicyNames[normalizeName(name)]
+	return policy, ok
+}
+
+// AllowedFillPolicies returns the fill policies a symbol group allows.
+// SymGroup.FillPolicy is either a list of names or an MT5 filling mode
+// bitmask (1 FOK, 2 IOC), in which case Return is allowed unless the
+// symbol uses market execution. It returns nil when the group does not say.
+func AllowedFillPolicies(group SymGroup) []FillPolicy {
+	if mask, err := strconv.ParseInt(strings.TrimSpace(group.FillPolicy), 10, 32); err == nil {
+		var allowed []FillPolicy
+		if mask&1 != 0 {
+			allowed = append(allowed, FillFOK)
+		}
+		if mask&2 != 0 {
+			allowed = append(allowed, FillIOC)
+		}
+		if !strings.Contains(normalizeName(group.TradeType), "MARKET") {
+			allowed = append(allowed, FillReturn)
+		}
+		return allowed
+	}
+
+	var allowed []FillPolicy
+	for _, name := range strings.FieldsFunc(group.FillPolicy, func(r rune) bool {
+		return r == ',' || r == '|' || r == ' '
+	}) {
+		if policy, ok := ParseFillPolicy(name); ok && !slices.Contains(allowed, policy) {
+			allowed = append(allowed, policy)
+		}
+	}
+	return allowed
+}
+
+// SelectFillPolicy returns a fill policy the symbol group allows for the
+// order type, preferring FOK for market orders and Return for pending
+// orders. It returns "" when the group does not list its policies.
+func SelectFillPolicy(group SymGroup, operation OrderType) FillPolicy {
+	preference := []FillPolicy{FillFOK, FillIOC, FillReturn}
+	if !isMarketOrder(operation) {
+		preference = []FillPolicy{FillReturn, FillFOK, FillIOC}
+	}
+
+	allowed := AllowedFillPolicies(group)
+	for _, policy := range preference {
+		if slices.Contains(allowed, policy) {
+			return policy
+		}
+	}
+	return ""
+}
+
+// selectFillPolicy picks a fill policy for an order without one from the
+// symbol metadata. It returns "" when the metadata cannot be loaded, leaving
+// the choice to the server rather than failing the order.
+func (c *Client) selectFillPolicy(ctx context.Context, req OrderSendRequest) FillPolicy {
+	params, err := c.symbolParams(ctx, req.Symbol)
+	if err != nil {
+		return ""
+	}
+	return SelectFillPolicy(params.SymbolGroup, req.Operation)
+}
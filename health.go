@@ -0,0 +1,248 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// HealthState represents the health of the bridge to the broker
+type HealthState string
+
+const (
+	HealthConnected    HealthState = "Connected"
+	HealthDegraded     HealthState = "Degraded"
+	HealthReconnecting HealthState = "Reconnecting"
+	HealthDown         HealthState = "Down"
+)
+
+// HealthEvent describes a health state transition
+type HealthEvent struct {
+	From    HealthState
+	To      HealthState
+	Time    time.Time
+	Latency time.Duration // Latency of the check that caused the transition
+	P50     time.Duration
+	P95     time.Duration
+	PingMs  int // Broker ping, if PingHost is configured
+	Err     error
+}
+
+// HealthConfig configures a HealthMonitor
+type HealthConfig struct {
+	Interval         time.Duration // Time between checks, default 10s
+	Timeout          time.Duration // Timeout of a single check, default 5s
+	DegradedLatency  time.Duration // p95 latency considered degraded, default 1s
+	FailureThreshold int           // Consecutive failures before reconnecting, default 3
+	Window           int           // Number of latency samples kept, default 50
+
+	// PingHost and PingPort optionally measure latency to the broker
+	PingHost string
+	PingPort int
+
+	// Session is used to reconnect once FailureThreshold is reached
+	Session *SessionManager
+	// Failover is called when reconnecting fails or no Session is set
+	Failover func(ctx context.Context) error
+}
+
+// HealthMonitor periodically checks the connection using CheckConnect
+type HealthMonitor struct {
+	client *Client
+	config HealthConfig
+	events chan HealthEvent
+
+	mu       sync.Mutex
+	state    HealthState
+	samples  []time.Duration
+	next     int
+	failures int
+	pingMs   int
+}
+
+// NewHealthMonitor creates a health monitor for the client
+func NewHealthMonitor(c *Client, config HealthConfig) *HealthMonitor {
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.DegradedLatency <= 0 {
+		config.DegradedLatency = time.Second
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 3
+	}
+	if config.Window <= 0 {
+		config.Window = 50
+	}
+
+	return &HealthMonitor{
+		client: c,
+		config: config,
+		events: make(chan HealthEvent, 16),
+		state:  HealthConnected,
+	}
+}
+
+// Events returns the channel of state transitions. Events are dropped when
+// the channel buffer is full; it is closed when Run returns.
+func (m *HealthMonitor) Events() <-chan HealthEvent {
+	return m.events
+}
+
+// State returns the current health state
+func (m *HealthMonitor) State() HealthState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Latency returns the rolling p50 and p95 check latency
+func (m *HealthMonitor) Latency() (p50, p95 time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.percentiles()
+}
+
+// Run performs checks until ctx is done
+func (m *HealthMonitor) Run(ctx context.Context) {
+	defer close(m.events)
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check performs a single health check
+func (m *HealthMonitor) check(ctx context.Context) {
+	// Probes see raw failures: no retries and no transparent reconnect
+	probeCtx := context.WithValue(context.WithValue(ctx, noRetryKey{}, true), sessionBypassKey{}, true)
+	checkCtx, cancel := context.WithTimeout(probeCtx, m.config.Timeout)
+	start := time.Now()
+	_, err := m.client.CheckConnect(checkCtx)
+	latency := time.Since(start)
+	if err == nil && m.config.PingHost != "" {
+		if pingMs, pingErr := m.client.PingHost(checkCtx, m.config.PingHost, m.config.PingPort); pingErr == nil {
+			m.mu.Lock()
+			m.pingMs = pingMs
+			m.mu.Unlock()
+		}
+	}
+	cancel()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err == nil {
+		m.mu.Lock()
+		m.record(latency)
+		m.failures = 0
+		_, p95 := m.percentiles()
+		m.mu.Unlock()
+
+		if p95 > m.config.DegradedLatency {
+			m.transition(HealthDegraded, latency, nil)
+		} else {
+			m.transition(HealthConnected, latency, nil)
+		}
+		return
+	}
+
+	m.mu.Lock()
+	m.failures++
+	failures := m.failures
+	m.mu.Unlock()
+
+	if failures < m.config.FailureThreshold {
+		m.transition(HealthDegraded, latency, err)
+		return
+	}
+
+	m.recover(ctx, err)
+}
+
+// recover attempts a reconnect and then failover
+func (m *HealthMonitor) recover(ctx context.Context, cause error) {
+	m.transition(HealthReconnecting, 0, cause)
+
+	err := errors.New("no session or failover configured")
+	if m.config.Session != nil {
+		err = m.config.Session.Reconnect(ctx)
+	}
+	if err != nil && m.config.Failover != nil {
+		err = m.config.Failover(ctx)
+	}
+
+	if err != nil {
+		m.transition(HealthDown, 0, errors.Join(cause, err))
+		return
+	}
+
+	m.mu.Lock()
+	m.failures = 0
+	m.mu.Unlock()
+	m.transition(HealthConnected, 0, nil)
+}
+
+// transition updates the state and emits an event on change
+func (m *HealthMonitor) transition(state HealthState, latency time.Duration, err error) {
+	m.mu.Lock()
+	from := m.state
+	m.state = state
+	p50, p95 := m.percentiles()
+	pingMs := m.pingMs
+	m.mu.Unlock()
+
+	if from == state {
+		return
+	}
+
+	event := HealthEvent{
+		From:    from,
+		To:      state,
+		Time:    time.Now(),
+		Latency: latency,
+		P50:     p50,
+		P95:     p95,
+		PingMs:  pingMs,
+		Err:     err,
+	}
+	select {
+	case m.events <- event:
+	default:
+	}
+}
+
+// record stores a latency sample; callers must hold m.mu
+func (m *HealthMonitor) record(latency time.Duration) {
+	if len(m.samples) < m.config.Window {
+		m.samples = append(m.samples, latency)
+		return
+	}
+	m.samples[m.next] = latency
+	m.next = (m.next + 1) % m.config.Window
+}
+
+// percentiles computes p50 and p95; callers must hold m.mu
+func (m *HealthMonitor) percentiles() (p50, p95 time.Duration) {
+	if len(m.samples) == 0 {
+		return 0, 0
+	}
+	sorted := slices.Clone(m.samples)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)-1)*50/100], sorted[(len(sorted)-1)*95/100]
+}
@@ -0,0 +1,225 @@
+package mt5api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxCommentLength is the longest order comment MT5 stores
+const maxCommentLength = 31
+
+// SubmitStatus describes the outcome of SubmitOrder
+type SubmitStatus string
+
+const (
+	SubmitConfirmed         SubmitStatus = "Confirmed"         // OrderSend succeeded
+	SubmitFoundAfterTimeout SubmitStatus = "FoundAfterTimeout" // OrderSend failed but the order exists
+	SubmitNotPlaced         SubmitStatus = "NotPlaced"         // The broker rejected the order
+	SubmitUnknown           SubmitStatus = "Unknown"           // The order was not found but may still exist
+)
+
+// SubmitOptions configures SubmitOrder
+type SubmitOptions struct {
+	// ClientOrderID tags the order comment; generated when empty
+	ClientOrderID string
+	// MaxAttempts limits OrderSend calls (default 1, never resend). An order
+	// not found by any search may still be processed by the broker, so a
+	// resend can open a duplicate position.
+	MaxAttempts int
+	// ReconcileSearches is the number of searches before the order is
+	// considered not found (default 3)
+	ReconcileSearches int
+	// ReconcileDelay is the wait before each search (default 1s)
+	ReconcileDelay time.Duration
+	// ReconcileTimeout bounds all searches, which run even when ctx is done
+	// (default 30s)
+	ReconcileTimeout time.Duration
+	// HistoryLookback widens the order history search window (default 10m)
+	HistoryLookback time.Duration
+}
+
+// SubmitResult reports the outcome of SubmitOrder
+type SubmitResult struct {
+	ClientOrderID string
+	Status        SubmitStatus
+	Order         *Order // The order, set when Confirmed or FoundAfterTimeout
+	Attempts      int    // Number of OrderSend calls
+	Err           error  // Last OrderSend error
+}
+
+// NewClientOrderID returns a random client order ID
+func NewClientOrderID() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SubmitOrder sends an order tagged with a client order ID in its comment.
+// When OrderSend fails with a timeout or another error that leaves the
+// outcome unknown, open orders and recent history are searched for the tag
+// several times. An order still not found is reported as SubmitUnknown and
+// only resent when MaxAttempts allows it. The error is nil when the order
+// was placed.
+func (c *Client) SubmitOrder(ctx context.Context, req OrderSendRequest, opts SubmitOptions) (*SubmitResult, error) {
+	if opts.ClientOrderID == "" {
+		opts.ClientOrderID = NewClientOrderID()
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	if opts.ReconcileSearches <= 0 {
+		opts.ReconcileSearches = 3
+	}
+	if opts.ReconcileDelay <= 0 {
+		opts.ReconcileDelay = time.Second
+	}
+	if opts.ReconcileTimeout <= 0 {
+		opts.ReconcileTimeout = 30 * time.Second
+	}
+	if opts.HistoryLookback <= 0 {
+		opts.HistoryLookback = 10 * time.Minute
+	}
+
+	req.Comment = tagComment(req.Comment, opts.ClientOrderID)
+	result := &SubmitResult{ClientOrderID: opts.ClientOrderID}
+	started := time.Now()
+
+	for result.Attempts < opts.MaxAttempts {
+		result.Attempts++
+		order, err := c.OrderSend(ctx, req)
+		if err == nil {
+			result.Status = SubmitConfirmed
+			result.Order = order
+			result.Err = nil
+			return result, nil
+		}
+		result.Err = err
+		if !isAmbiguous(err) {
+			result.Status = SubmitNotPlaced
+			return result, err
+		}
+
+		order, status, err := c.reconcileOrder(ctx, opts, started)
+		if err != nil {
+			result.Status = SubmitUnknown
+			return result, fmt.Errorf("reconciling order %s: %w (send error: %v)", opts.ClientOrderID, err, result.Err)
+		}
+		result.Status = status
+		if status == SubmitFoundAfterTimeout {
+			result.Order = order
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return result, result.Err
+}
+
+// reconcileOrder repeatedly searches for the client order ID. It reports
+// SubmitFoundAfterTimeout with the order, SubmitNotPlaced when the broker
+// rejected it, or SubmitUnknown when no search found it.
+func (c *Client) reconcileOrder(ctx context.Context, opts SubmitOptions, started time.Time) (*Order, SubmitStatus, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), opts.ReconcileTimeout)
+	defer cancel()
+
+	for range opts.ReconcileSearches {
+		if err := sleepContext(ctx, opts.ReconcileDelay); err != nil {
+			return nil, SubmitUnknown, err
+		}
+		order, found, err := c.searchOrder(ctx, opts, started)
+		if err != nil {
+			return nil, SubmitUnknown, err
+		}
+		if order != nil {
+			return order, SubmitFoundAfterTimeout, nil
+		}
+		if found {
+			return nil, SubmitNotPlaced, nil
+		}
+	}
+	return nil, SubmitUnknown, nil
+}
+
+// searchOrder searches open orders and recent history once for the client
+// order ID. It returns found true with a nil order when the order was
+// rejected.
+func (c *Client) searchOrder(ctx context.Context, opts SubmitOptions, started time.Time) (*Order, bool, error) {
+	opened, err := c.OpenedOrders(ctx, "", false)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range opened {
+		if strings.Contains(opened[i].Comment, opts.ClientOrderID) {
+			return &opened[i], true, nil
+		}
+	}
+
+	from := c.toServerTime(started.Add(-opts.HistoryLookback))
+	to := c.toServerTime(time.Now().Add(opts.HistoryLookback))
+	history, err := c.OrderHistory(ctx, from, to, "", false, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range history.Orders {
+		if strings.Contains(history.Orders[i].Comment, opts.ClientOrderID) {
+			return &history.Orders[i], true, nil
+		}
+	}
+	for _, internal := range history.InternalOrders {
+		if !strings.Contains(internal.Comment, opts.ClientOrderID) {
+			continue
+		}
+		if internal.State == StateRejected {
+			return nil, true, nil
+		}
+		return &Order{
+			Ticket:         internal.Ticket,
+			Symbol:         internal.Symbol,
+			OrderType:      internal.Type,
+			Lots:           internal.Lots,
+			OpenPrice:      internal.OpenPrice,
+			StopLoss:       internal.StopLoss,
+			TakeProfit:     internal.TakeProfit,
+			StopLimitPrice: internal.StopLimitPrice,
+			ExpertId:       internal.ExpertId,
+			PlacedType:     internal.PlacedType,
+			Comment:        internal.Comment,
+			State:          internal.State,
+			Digits:         internal.Digits,
+			ContractSize:   internal.ContractSize,
+		}, true, nil
+	}
+	return nil, false, nil
+}
+
+// isAmbiguous reports whether an OrderSend error leaves it unknown whether
+// the order reached the broker
+func isAmbiguous(err error) bool {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return false
+	}
+	return errors.Is(err, ErrTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		IsRetryable(err)
+}
+
+// tagComment prefixes comment with the client order ID, truncating it to
+// the MT5 comment length
+func tagComment(comment, clientOrderID string) string {
+	tagged := clientOrderID
+	if comment != "" {
+		tagged += " " + comment
+	}
+	if runes := []rune(tagged); len(runes) > maxCommentLength {
+		tagged = string(runes[:maxCommentLength])
+	}
+	return tagged
+}
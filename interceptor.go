@@ -0,0 +1,45 @@
+package mt5api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Call describes a single API request passed through interceptors
+type Call struct {
+	Method   string
+	Endpoint string
+	Params   url.Values // Query parameters including the session token
+	Header   http.Header
+}
+
+// CallResult describes the HTTP response of a call. It is returned together
+// with an *APIError when the API reports an error.
+type CallResult struct {
+	StatusCode int
+	Body       []byte
+	Latency    time.Duration
+}
+
+// RoundTrip performs a call and returns its result
+type RoundTrip func(ctx context.Context, call *Call) (*CallResult, error)
+
+// Interceptor wraps a RoundTrip to observe or modify calls, e.g. for
+// logging, metrics, tracing, header injection or fault injection
+type Interceptor func(next RoundTrip) RoundTrip
+
+// Use appends interceptors to the client chain. It must be called before the
+// client is shared between goroutines.
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.Interceptors = append(c.Interceptors, interceptors...)
+}
+
+// chainInterceptors wraps rt so that the first interceptor runs first
+func chainInterceptors(rt RoundTrip, interceptors []Interceptor) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		rt = interceptors[i](rt)
+	}
+	return rt
+}
@@ -0,0 +1,191 @@
+package mt5api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket; a zero Rate means unlimited
+type RateLimit struct {
+	Rate  float64 // Requests per second
+	Burst int     // Maximum requests allowed at once, default 1
+}
+
+// WaitStats reports how long requests waited on the limiter
+type WaitStats struct {
+	Requests  int64         // Requests that passed the limiter
+	Waited    int64         // Requests that had to wait
+	TotalWait time.Duration // Sum of all waits
+	MaxWait   time.Duration // Longest single wait
+}
+
+// Limiter throttles requests with a global and per-endpoint token bucket and
+// caps the number of requests in flight. Waiting respects the request context.
+type Limiter struct {
+	// OnWait is called whenever a request had to wait before being sent
+	OnWait func(endpoint string, wait time.Duration)
+
+	mu        sync.Mutex
+	global    *tokenBucket
+	endpoints map[string]*tokenBucket
+	stats     map[string]*WaitStats
+	inFlight  chan struct{}
+}
+
+// NewLimiter creates a limiter with a global rate limit and in-flight cap;
+// maxInFlight <= 0 disables the cap
+func NewLimiter(global RateLimit, maxInFlight int) *Limiter {
+	l := &Limiter{
+		global:    newTokenBucket(global),
+		endpoints: make(map[string]*tokenBucket),
+		stats:     make(map[string]*WaitStats),
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// SetEndpointLimit sets a rate limit for one endpoint, e.g. "/OrderSend",
+// applied in addition to the global limit
+func (l *Limiter) SetEndpointLimit(endpoint string, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.endpoints[endpoint] = newTokenBucket(limit)
+}
+
+// Stats returns the wait statistics per endpoint
+func (l *Limiter) Stats() map[string]WaitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := make(map[string]WaitStats, len(l.stats))
+	for endpoint, s := range l.stats {
+		stats[endpoint] = *s
+	}
+	return stats
+}
+
+// Wait blocks until the endpoint may be called or ctx is done. The returned
+// release function must be called once the request completes.
+func (l *Limiter) Wait(ctx context.Context, endpoint string) (release func(), err error) {
+	start := time.Now()
+
+	l.mu.Lock()
+	endpointBucket := l.endpoints[endpoint]
+	delay := l.global.reserve(start)
+	if d := endpointBucket.reserve(start); d > delay {
+		delay = d
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		if err := sleepContext(ctx, delay); err != nil {
+			l.cancel(endpointBucket)
+			return nil, err
+		}
+	}
+
+	release = func() {}
+	if l.inFlight != nil {
+		select {
+		case <-ctx.Done():
+			l.cancel(endpointBucket)
+			return nil, ctx.Err()
+		case l.inFlight <- struct{}{}:
+		}
+		release = func() { <-l.inFlight }
+	}
+
+	l.record(endpoint, time.Since(start))
+	return release, nil
+}
+
+// cancel returns the tokens reserved by an abandoned Wait
+func (l *Limiter) cancel(endpointBucket *tokenBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.global.cancel()
+	endpointBucket.cancel()
+}
+
+// record updates wait statistics and notifies OnWait
+func (l *Limiter) record(endpoint string, wait time.Duration) {
+	// Ignore scheduling noise when no delay was imposed
+	waited := wait >= time.Millisecond
+
+	l.mu.Lock()
+	s, ok := l.stats[endpoint]
+	if !ok {
+		s = &WaitStats{}
+		l.stats[endpoint] = s
+	}
+	s.Requests++
+	if waited {
+		s.Waited++
+		s.TotalWait += wait
+		if wait > s.MaxWait {
+			s.MaxWait = wait
+		}
+	}
+	l.mu.Unlock()
+
+	if waited && l.OnWait != nil {
+		l.OnWait(endpoint, wait)
+	}
+}
+
+// tokenBucket is a token bucket that allows reservations into the future
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket, or nil for an unlimited rate
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token
+func (b *tokenBucket) cancel() {
+	if b == nil {
+		return
+	}
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,41 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// logInterceptor logs each call with secret parameters redacted
+func (c *Client) logInterceptor(next RoundTrip) RoundTrip {
+	return func(ctx context.Context, call *Call) (*CallResult, error) {
+		start := time.Now()
+		result, err := next(ctx, call)
+
+		attrs := []slog.Attr{
+			slog.String("method", call.Method),
+			slog.String("endpoint", call.Endpoint),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if result != nil {
+			attrs = append(attrs, slog.Int("status", result.StatusCode))
+		}
+
+		if err == nil {
+			c.Logger.LogAttrs(ctx, c.LogLevel, "mt5api call", attrs...)
+			return result, nil
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code != "" {
+			attrs = append(attrs, slog.String("code", apiErr.Code))
+		}
+		attrs = append(attrs,
+			slog.Any("params", redactParams(call.Params)),
+			slog.String("error", err.Error()),
+		)
+		c.Logger.LogAttrs(ctx, c.ErrorLogLevel, "mt5api call failed", attrs...)
+		return result, err
+	}
+}
@@ -0,0 +1,207 @@
+package mt5api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MarketCache keeps the latest market data per symbol, fed by the quote,
+// market watch and OHLC streams. Lookups do not take locks.
+type MarketCache struct {
+	client *Client
+	maxAge time.Duration
+
+	entries sync.Map // symbol -> *marketEntry
+	symbols atomic.Pointer[map[string]SymbolInfo]
+	loadMu  sync.Mutex // serializes symbol loading
+}
+
+// marketEntry holds the cached data of one symbol
+type marketEntry struct {
+	quote atomic.Pointer[cachedQuote]
+	watch atomic.Pointer[MarketWatch]
+	ohlc  sync.Map // timeframe -> *OhlcSubscription
+}
+
+// cachedQuote is a quote with the time it was received
+type cachedQuote struct {
+	quote    Quote
+	received time.Time
+}
+
+// NewMarketCache creates a cache; quotes older than maxAge are refreshed
+// with GetQuote by Quote (default 5s)
+func NewMarketCache(c *Client, maxAge time.Duration) *MarketCache {
+	if maxAge <= 0 {
+		maxAge = 5 * time.Second
+	}
+	return &MarketCache{
+		client: c,
+		maxAge: maxAge,
+	}
+}
+
+// Run feeds the cache from the quote, market watch and OHLC streams until
+// ctx is done
+func (m *MarketCache) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		m.client.SocketOnQuote(ctx, m.UpdateQuote)
+	}()
+	go func() {
+		defer wg.Done()
+		m.client.SocketOnMarketWatch(ctx, m.UpdateMarketWatch)
+	}()
+	go func() {
+		defer wg.Done()
+		m.client.SocketOnOHLC(ctx, m.UpdateOHLC)
+	}()
+	wg.Wait()
+}
+
+// UpdateQuote stores a quote
+func (m *MarketCache) UpdateQuote(quote *Quote) {
+	m.entry(quote.Symbol).quote.Store(&cachedQuote{quote: *quote, received: time.Now()})
+}
+
+// UpdateMarketWatch stores market watch data
+func (m *MarketCache) UpdateMarketWatch(watch MarketWatch) {
+	m.entry(watch.Symbol).watch.Store(&watch)
+}
+
+// UpdateOHLC stores an OHLC bar
+func (m *MarketCache) UpdateOHLC(bar *OhlcSubscription) {
+	value := *bar
+	m.entry(bar.Symbol).ohlc.Store(bar.Timeframe, &value)
+}
+
+// LatestQuote returns the cached quote and its age
+func (m *MarketCache) LatestQuote(symbol string) (Quote, time.Duration, bool) {
+	entry, ok := m.lookup(symbol)
+	if !ok {
+		return Quote{}, 0, false
+	}
+	cached := entry.quote.Load()
+	if cached == nil {
+		return Quote{}, 0, false
+	}
+	return cached.quote, time.Since(cached.received), true
+}
+
+// Quote returns the cached quote, falling back to GetQuote when it is
+// missing or older than the cache maximum age
+func (m *MarketCache) Quote(ctx context.Context, symbol string) (*Quote, error) {
+	if quote, age, ok := m.LatestQuote(symbol); ok && age <= m.maxAge {
+		return &quote, nil
+	}
+
+	quote, err := m.client.GetQuote(ctx, symbol, int(m.maxAge.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+	m.UpdateQuote(quote)
+	return quote, nil
+}
+
+// MarketWatch returns the cached market watch data
+func (m *MarketCache) MarketWatch(symbol string) (MarketWatch, bool) {
+	entry, ok := m.lookup(symbol)
+	if !ok {
+		return MarketWatch{}, false
+	}
+	watch := entry.watch.Load()
+	if watch == nil {
+		return MarketWatch{}, false
+	}
+	return *watch, true
+}
+
+// DayRange returns the day high and low from market watch data
+func (m *MarketCache) DayRange(symbol string) (high, low float64, ok bool) {
+	watch, ok := m.MarketWatch(symbol)
+	if !ok {
+		return 0, 0, false
+	}
+	return watch.High, watch.Low, true
+}
+
+// OHLC returns the latest cached bar for a symbol and timeframe
+func (m *MarketCache) OHLC(symbol string, timeframe int32) (OhlcSubscription, bool) {
+	entry, ok := m.lookup(symbol)
+	if !ok {
+		return OhlcSubscription{}, false
+	}
+	bar, ok := entry.ohlc.Load(timeframe)
+	if !ok {
+		return OhlcSubscription{}, false
+	}
+	return *bar.(*OhlcSubscription), true
+}
+
+// SpreadPoints returns the spread of the cached quote in points, loading
+// symbol information on first use
+func (m *MarketCache) SpreadPoints(ctx context.Context, symbol string) (float64, error) {
+	quote, err := m.Quote(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	info, err := m.symbolInfo(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Round((quote.Ask - quote.Bid) / symbolPoint(info)), nil
+}
+
+// symbolInfo returns symbol information, loading all symbols once
+func (m *MarketCache) symbolInfo(ctx context.Context, symbol string) (SymbolInfo, error) {
+	if symbols := m.symbols.Load(); symbols != nil {
+		if info, ok := (*symbols)[symbol]; ok {
+			return info, nil
+		}
+	}
+
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+	if symbols := m.symbols.Load(); symbols != nil {
+		if info, ok := (*symbols)[symbol]; ok {
+			return info, nil
+		}
+	}
+
+	symbols, err := m.client.Symbols(ctx)
+	if err != nil {
+		return SymbolInfo{}, err
+	}
+	m.symbols.Store(&symbols)
+
+	info, ok := symbols[symbol]
+	if !ok {
+		return SymbolInfo{}, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	return info, nil
+}
+
+// entry returns the entry of a symbol, creating it if needed
+func (m *MarketCache) entry(symbol string) *marketEntry {
+	if entry, ok := m.entries.Load(symbol); ok {
+		return entry.(*marketEntry)
+	}
+	entry, _ := m.entries.LoadOrStore(symbol, &marketEntry{})
+	return entry.(*marketEntry)
+}
+
+// lookup returns the entry of a symbol if present
+func (m *MarketCache) lookup(symbol string) (*marketEntry, bool) {
+	entry, ok := m.entries.Load(symbol)
+	if !ok {
+		return nil, false
+	}
+	return entry.(*marketEntry), true
+}
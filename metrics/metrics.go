@@ -0,0 +1,187 @@
+// Package metrics collects MT5 API client activity and exposes it in the
+// Prometheus text exposition format without external dependencies.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ditthkr/mt5api"
+)
+
+var _ mt5api.MetricsCollector = (*Registry)(nil)
+
+// DefaultBuckets are the latency histogram buckets in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Registry implements mt5api.MetricsCollector and serves the collected
+// metrics over HTTP
+type Registry struct {
+	buckets []float64 // Latency buckets in seconds, fixed at creation
+
+	mu           sync.Mutex
+	requests     map[[2]string]uint64 // endpoint, status
+	errors       map[[2]string]uint64 // endpoint, code
+	latencies    map[string]*histogram
+	reconnects   map[string]uint64
+	messages     map[string]uint64
+	lastMessages map[string]time.Time
+}
+
+// histogram is a cumulative latency histogram
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewRegistry creates an empty registry with the given latency buckets in
+// seconds, or DefaultBuckets when none are given
+func NewRegistry(buckets ...float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &Registry{
+		buckets:      buckets,
+		requests:     make(map[[2]string]uint64),
+		errors:       make(map[[2]string]uint64),
+		latencies:    make(map[string]*histogram),
+		reconnects:   make(map[string]uint64),
+		messages:     make(map[string]uint64),
+		lastMessages: make(map[string]time.Time),
+	}
+}
+
+// ObserveRequest records a completed REST call
+func (r *Registry) ObserveRequest(endpoint string, status int, code string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[[2]string{endpoint, fmt.Sprint(status)}]++
+	if code != "" {
+		r.errors[[2]string{endpoint, code}]++
+	}
+
+	h, ok := r.latencies[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.latencies[endpoint] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range r.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ObserveReconnect records a WebSocket reconnect
+func (r *Registry) ObserveReconnect(endpoint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reconnects[streamName(endpoint)]++
+}
+
+// ObserveMessage records a message received on a WebSocket stream
+func (r *Registry) ObserveMessage(endpoint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stream := streamName(endpoint)
+	r.messages[stream]++
+	r.lastMessages[stream] = time.Now()
+}
+
+// Handler returns an HTTP handler serving the Prometheus text format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+// WriteTo writes all metrics in the Prometheus text format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	r.mu.Lock()
+	writeHeader(&b, "mt5api_requests_total", "counter", "Total REST requests by endpoint and HTTP status.")
+	for _, key := range sortedKeys(r.requests) {
+		fmt.Fprintf(&b, "mt5api_requests_total{endpoint=%s,status=%s} %d\n", quote(key[0]), quote(key[1]), r.requests[key])
+	}
+
+	writeHeader(&b, "mt5api_request_errors_total", "counter", "Total failed REST requests by endpoint and error code.")
+	for _, key := range sortedKeys(r.errors) {
+		fmt.Fprintf(&b, "mt5api_request_errors_total{endpoint=%s,code=%s} %d\n", quote(key[0]), quote(key[1]), r.errors[key])
+	}
+
+	writeHeader(&b, "mt5api_request_duration_seconds", "histogram", "REST request latency by endpoint.")
+	for _, endpoint := range sortedKeys(r.latencies) {
+		h := r.latencies[endpoint]
+		for i, bound := range r.buckets {
+			fmt.Fprintf(&b, "mt5api_request_duration_seconds_bucket{endpoint=%s,le=\"%g\"} %d\n", quote(endpoint), bound, h.counts[i])
+		}
+		fmt.Fprintf(&b, "mt5api_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", quote(endpoint), h.count)
+		fmt.Fprintf(&b, "mt5api_request_duration_seconds_sum{endpoint=%s} %g\n", quote(endpoint), h.sum)
+		fmt.Fprintf(&b, "mt5api_request_duration_seconds_count{endpoint=%s} %d\n", quote(endpoint), h.count)
+	}
+
+	writeHeader(&b, "mt5api_websocket_reconnects_total", "counter", "Total WebSocket reconnects by stream.")
+	for _, stream := range sortedKeys(r.reconnects) {
+		fmt.Fprintf(&b, "mt5api_websocket_reconnects_total{stream=%s} %d\n", quote(stream), r.reconnects[stream])
+	}
+
+	writeHeader(&b, "mt5api_stream_messages_total", "counter", "Total WebSocket messages received by stream.")
+	for _, stream := range sortedKeys(r.messages) {
+		fmt.Fprintf(&b, "mt5api_stream_messages_total{stream=%s} %d\n", quote(stream), r.messages[stream])
+	}
+
+	now := time.Now()
+	writeHeader(&b, "mt5api_stream_last_message_age_seconds", "gauge", "Seconds since the last WebSocket message by stream.")
+	for _, stream := range sortedKeys(r.lastMessages) {
+		fmt.Fprintf(&b, "mt5api_stream_last_message_age_seconds{stream=%s} %g\n", quote(stream), now.Sub(r.lastMessages[stream]).Seconds())
+	}
+	r.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// streamName converts an endpoint like "/OnQuote" to a stream label
+func streamName(endpoint string) string {
+	return strings.TrimPrefix(endpoint, "/")
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric family
+func writeHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// quote quotes a label value as required by the text format
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// sortedKeys returns map keys in a stable order
+func sortedKeys[K string | [2]string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
@@ -0,0 +1,159 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes an order field rejected by symbol rules
+type ValidationError struct {
+	Field   string
+	Message string
+	err     error
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap returns the matching sentinel, such as ErrInvalidVolume
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// NormalizeVolume rounds volume to the lot step of the symbol group and
+// checks it against the minimum and maximum lots
+func NormalizeVolume(volume float64, group SymGroup) (float64, error) {
+	if volume <= 0 {
+		return 0, &ValidationError{Field: "Volume", Message: fmt.Sprintf("volume %g must be positive", volume), err: ErrInvalidVolume}
+	}
+
+	if step := group.LotsStep; step > 0 {
+		volume = roundDecimals(math.Round(volume/step)*step, decimalPlaces(step))
+	}
+	if group.MinLots > 0 && volume < group.MinLots {
+		return 0, &ValidationError{Field: "Volume", Message: fmt.Sprintf("volume %g is below the minimum of %g lots", volume, group.MinLots), err: ErrInvalidVolume}
+	}
+	if group.MaxLots > 0 && volume > group.MaxLots {
+		return 0, &ValidationError{Field: "Volume", Message: fmt.Sprintf("volume %g exceeds the maximum of %g lots", volume, group.MaxLots), err: ErrInvalidVolume}
+	}
+	return volume, nil
+}
+
+// NormalizePrice rounds price to the tick size and digits of the symbol
+func NormalizePrice(price float64, info SymbolInfo) float64 {
+	if price == 0 {
+		return 0
+	}
+	if info.TickSize > 0 {
+		price = math.Round(price/info.TickSize) * info.TickSize
+	}
+	return roundDecimals(price, int(info.Digits))
+}
+
+// ValidateStops checks that stop loss and take profit keep the minimum
+// distance in points from the reference price required by the symbol group.
+// For market orders the reference is the price the position closes at.
+func ValidateStops(params SymbolParams, reference, stopLoss, takeProfit float64) error {
+	point := symbolPoint(params.SymbolInfo)
+	check := func(field string, level int32, price float64) error {
+		if price <= 0 || level <= 0 || reference <= 0 {
+			return nil
+		}
+		distance := math.Round(math.Abs(reference-price) / point)
+		if distance >= float64(level) {
+			return nil
+		}
+		return &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("%g is %g points from %g, the minimum distance is %d points", price, distance, reference, level),
+			err:     ErrInvalidStops,
+		}
+	}
+	return errors.Join(
+		check("StopLoss", params.SymbolGroup.SL, stopLoss),
+		check("TakeProfit", params.SymbolGroup.TP, takeProfit),
+	)
+}
+
+// NormalizeOrderSend returns req with volume and prices rounded to the
+// symbol rules. Stop distances are checked against the order price, or
+// against quote for market orders when quote is not nil.
+func NormalizeOrderSend(req OrderSendRequest, params SymbolParams, quote *Quote) (OrderSendRequest, error) {
+	volume, err := NormalizeVolume(req.Volume, params.SymbolGroup)
+	if err != nil {
+		return req, err
+	}
+	req.Volume = volume
+	req.Price = NormalizePrice(req.Price, params.SymbolInfo)
+	req.StopLoss = NormalizePrice(req.StopLoss, params.SymbolInfo)
+	req.TakeProfit = NormalizePrice(req.TakeProfit, params.SymbolInfo)
+	req.StopLimitPrice = NormalizePrice(req.StopLimitPrice, params.SymbolInfo)
+
+	reference := stopReference(req, quote)
+	return req, ValidateStops(params, reference, req.StopLoss, req.TakeProfit)
+}
+
+// NormalizeOrderSend rounds volume and prices of req to the symbol rules
+// and validates stop distances, fetching a quote for market orders
+func (c *Client) NormalizeOrderSend(ctx context.Context, req OrderSendRequest) (OrderSendRequest, error) {
+	params, err := c.symbolParams(ctx, req.Symbol)
+	if err != nil {
+		return req, err
+	}
+
+	var quote *Quote
+	if isMarketOrder(req.Operation) && (req.StopLoss > 0 || req.TakeProfit > 0) {
+		if quote, err = c.GetQuote(ctx, req.Symbol, 0); err != nil {
+			return req, err
+		}
+	}
+	return NormalizeOrderSend(req, *params, quote)
+}
+
+// stopReference returns the price stop distances are measured from
+func stopReference(req OrderSendRequest, quote *Quote) float64 {
+	switch req.Operation {
+	case OrderBuy:
+		if quote != nil {
+			return quote.Bid
+		}
+	case OrderSell:
+		if quote != nil {
+			return quote.Ask
+		}
+	case OrderBuyStopLimit, OrderSellStopLimit:
+		if req.StopLimitPrice > 0 {
+			return req.StopLimitPrice
+		}
+	}
+	return req.Price
+}
+
+// isMarketOrder reports whether the order type executes at market
+func isMarketOrder(operation OrderType) bool {
+	return operation == OrderBuy || operation == OrderSell
+}
+
+// decimalPlaces returns the number of decimals of a step such as 0.01
+func decimalPlaces(step float64) int {
+	s := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// roundDecimals rounds value to the given number of decimals
+func roundDecimals(value float64, decimals int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	if err != nil {
+		return value
+	}
+	return rounded
+}
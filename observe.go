@@ -0,0 +1,67 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// MetricsCollector receives client activity. The metrics subpackage provides
+// an implementation with Prometheus text exposition.
+type MetricsCollector interface {
+	// ObserveRequest records a completed REST call; code is empty on success
+	ObserveRequest(endpoint string, status int, code string, duration time.Duration)
+	// ObserveReconnect records a WebSocket reconnect
+	ObserveReconnect(endpoint string)
+	// ObserveMessage records a message received on a WebSocket stream
+	ObserveMessage(endpoint string)
+}
+
+// metricsInterceptor reports each call to the metrics collector
+func (c *Client) metricsInterceptor(next RoundTrip) RoundTrip {
+	return func(ctx context.Context, call *Call) (*CallResult, error) {
+		start := time.Now()
+		result, err := next(ctx, call)
+
+		status := 0
+		if result != nil {
+			status = result.StatusCode
+		}
+		c.Metrics.ObserveRequest(call.Endpoint, status, errorCode(err), time.Since(start))
+		return result, err
+	}
+}
+
+// observeReconnect reports a WebSocket reconnect
+func (c *Client) observeReconnect(endpoint string) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveReconnect(endpoint)
+	}
+}
+
+// observeMessage reports a received WebSocket message
+func (c *Client) observeMessage(endpoint string) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveMessage(endpoint)
+	}
+}
+
+// errorCode returns a short label for err: the API error code, the HTTP
+// status for non-API failures, or "network"
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Code != "" {
+			return apiErr.Code
+		}
+		return "HTTP_" + strconv.Itoa(apiErr.StatusCode)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "canceled"
+	}
+	return "network"
+}
@@ -0,0 +1,217 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccountPool manages sessions for many accounts on the same API host,
+// sharing one HTTP client
+type AccountPool struct {
+	BaseURL     string
+	HTTPClient  *http.Client
+	Concurrency int // Maximum parallel calls in fan-out operations, default 8
+
+	mu       sync.RWMutex
+	accounts map[int64]*poolAccount
+}
+
+// poolAccount holds the client and session of a pooled account
+type poolAccount struct {
+	client  *Client
+	session *SessionManager
+}
+
+// ErrAccountExists is returned when adding a login already in the pool
+var ErrAccountExists = errors.New("mt5api: account already in pool")
+
+// AccountResult holds the outcome of a fan-out call for one account
+type AccountResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// NewAccountPool creates an empty account pool
+func NewAccountPool(baseURL string) *AccountPool {
+	return &AccountPool{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		Concurrency: 8,
+		accounts:    make(map[int64]*poolAccount),
+	}
+}
+
+// Add registers an account with a credential provider and returns its client.
+// The client reconnects automatically through its SessionManager. Adding a
+// login already in the pool fails with ErrAccountExists; Remove it first.
+func (p *AccountPool) Add(login int64, credentials CredentialProvider) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.accounts[login]; ok {
+		return nil, fmt.Errorf("%w: %d", ErrAccountExists, login)
+	}
+
+	client := NewClient(p.BaseURL)
+	client.HTTPClient = p.HTTPClient
+	session := NewSessionManager(client, credentials)
+	p.accounts[login] = &poolAccount{client: client, session: session}
+	return client, nil
+}
+
+// AddConnect registers an account connected with host and port
+func (p *AccountPool) AddConnect(req ConnectRequest) (*Client, error) {
+	return p.Add(req.User, ConnectCredentials(func(context.Context) (ConnectRequest, error) {
+		return req, nil
+	}))
+}
+
+// AddConnectEx registers an account connected with server name
+func (p *AccountPool) AddConnectEx(req ConnectExRequest) (*Client, error) {
+	return p.Add(req.User, ConnectExCredentials(func(context.Context) (ConnectExRequest, error) {
+		return req, nil
+	}))
+}
+
+// Get returns the client for a login
+func (p *AccountPool) Get(login int64) (*Client, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	account, ok := p.accounts[login]
+	if !ok {
+		return nil, false
+	}
+	return account.client, true
+}
+
+// Session returns the session manager for a login
+func (p *AccountPool) Session(login int64) (*SessionManager, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	account, ok := p.accounts[login]
+	if !ok {
+		return nil, false
+	}
+	return account.session, true
+}
+
+// Logins returns the registered logins in ascending order
+func (p *AccountPool) Logins() []int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	logins := make([]int64, 0, len(p.accounts))
+	for login := range p.accounts {
+		logins = append(logins, login)
+	}
+	slices.Sort(logins)
+	return logins
+}
+
+// Connect connects all registered accounts that are not connected yet
+func (p *AccountPool) Connect(ctx context.Context) error {
+	results := FanOut(ctx, p, func(ctx context.Context, login int64, c *Client) (struct{}, error) {
+		session, _ := p.Session(login)
+		if session == nil || session.State() == ConnectionConnected {
+			return struct{}{}, nil
+		}
+		return struct{}{}, session.Connect(ctx)
+	})
+	return joinResults(results)
+}
+
+// Remove disconnects an account and removes it from the pool
+func (p *AccountPool) Remove(ctx context.Context, login int64) error {
+	p.mu.Lock()
+	account, ok := p.accounts[login]
+	delete(p.accounts, login)
+	p.mu.Unlock()
+
+	if !ok || account.session.State() == ConnectionDisconnected {
+		return nil
+	}
+	return account.session.Disconnect(ctx)
+}
+
+// Close disconnects all accounts and empties the pool
+func (p *AccountPool) Close(ctx context.Context) error {
+	results := FanOut(ctx, p, func(ctx context.Context, login int64, c *Client) (struct{}, error) {
+		return struct{}{}, p.Remove(ctx, login)
+	})
+	return joinResults(results)
+}
+
+// AccountSummaries fetches the account summary of every account
+func (p *AccountPool) AccountSummaries(ctx context.Context) map[int64]AccountResult[*AccountSummary] {
+	return FanOut(ctx, p, func(ctx context.Context, login int64, c *Client) (*AccountSummary, error) {
+		return c.AccountSummary(ctx)
+	})
+}
+
+// FanOut calls fn for every account concurrently, bounded by the pool
+// Concurrency, and collects the results by login
+func FanOut[T any](ctx context.Context, p *AccountPool, fn func(ctx context.Context, login int64, c *Client) (T, error)) map[int64]AccountResult[T] {
+	logins := p.Logins()
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[int64]AccountResult[T], len(logins))
+		sem     = make(chan struct{}, concurrency)
+	)
+	for _, login := range logins {
+		client, ok := p.Get(login)
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			results[login] = AccountResult[T]{Err: ctx.Err()}
+			mu.Unlock()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			value, err := fn(ctx, login, client)
+			mu.Lock()
+			results[login] = AccountResult[T]{Value: value, Err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// joinResults joins the errors of a fan-out, annotated with the login
+func joinResults[T any](results map[int64]AccountResult[T]) error {
+	logins := make([]int64, 0, len(results))
+	for login := range results {
+		logins = append(logins, login)
+	}
+	slices.Sort(logins)
+
+	var errs []error
+	for _, login := range logins {
+		if err := results[login].Err; err != nil {
+			errs = append(errs, fmt.Errorf("account %d: %w", login, err))
+		}
+	}
+	return errors.Join(errs...)
+}
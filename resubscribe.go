@@ -0,0 +1,159 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// OHLCKey identifies an OHLC subscription
+type OHLCKey struct {
+	Symbol    string
+	Timeframe int
+}
+
+// SubscriptionSet describes the server-side subscriptions the client keeps
+// alive across WebSocket and session reconnects
+type SubscriptionSet struct {
+	Quotes              map[string]int  // Update interval by symbol
+	OHLC                map[OHLCKey]int // Update interval by symbol and timeframe
+	OrderProfit         bool
+	OrderProfitInterval int
+}
+
+// subscriptionTracker records the desired subscription set
+type subscriptionTracker struct {
+	mu  sync.Mutex
+	set SubscriptionSet
+}
+
+// Subscriptions returns the subscriptions replayed after reconnects. Use
+// Subscribe, SubscribeMany, UnSubscribe and UnSubscribeMany to change the
+// quote symbols at runtime.
+func (c *Client) Subscriptions() SubscriptionSet {
+	t := &c.subscriptions
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return SubscriptionSet{
+		Quotes:              maps.Clone(t.set.Quotes),
+		OHLC:                maps.Clone(t.set.OHLC),
+		OrderProfit:         t.set.OrderProfit,
+		OrderProfitInterval: t.set.OrderProfitInterval,
+	}
+}
+
+// Resubscribe replays all tracked subscriptions on the server
+func (c *Client) Resubscribe(ctx context.Context) error {
+	return errors.Join(
+		c.resubscribeQuotes(ctx),
+		c.resubscribeOHLC(ctx),
+		c.resubscribeOrderProfit(ctx),
+	)
+}
+
+// resubscribeEndpoint replays the subscriptions feeding a WebSocket endpoint
+func (c *Client) resubscribeEndpoint(ctx context.Context, endpoint string) error {
+	switch endpoint {
+	case "/OnQuote":
+		return c.resubscribeQuotes(ctx)
+	case "/OnOhlc":
+		return c.resubscribeOHLC(ctx)
+	case "/OnOrderProfit":
+		return c.resubscribeOrderProfit(ctx)
+	}
+	return nil
+}
+
+// resubscribeQuotes replays quote subscriptions grouped by interval
+func (c *Client) resubscribeQuotes(ctx context.Context) error {
+	byInterval := make(map[int][]string)
+	for symbol, interval := range c.Subscriptions().Quotes {
+		byInterval[interval] = append(byInterval[interval], symbol)
+	}
+
+	var errs []error
+	for _, interval := range slices.Sorted(maps.Keys(byInterval)) {
+		symbols := byInterval[interval]
+		slices.Sort(symbols)
+		if _, err := c.SubscribeMany(ctx, symbols, interval); err != nil {
+			errs = append(errs, fmt.Errorf("resubscribing quotes: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resubscribeOHLC replays OHLC subscriptions
+func (c *Client) resubscribeOHLC(ctx context.Context) error {
+	var errs []error
+	for key, interval := range c.Subscriptions().OHLC {
+		if _, err := c.SubscribeOHLC(ctx, key.Symbol, key.Timeframe, interval); err != nil {
+			errs = append(errs, fmt.Errorf("resubscribing OHLC %s: %w", key.Symbol, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resubscribeOrderProfit replays the order profit subscription
+func (c *Client) resubscribeOrderProfit(ctx context.Context) error {
+	set := c.Subscriptions()
+	if !set.OrderProfit {
+		return nil
+	}
+	if _, err := c.SubscribeOrderProfit(ctx, set.OrderProfitInterval); err != nil {
+		return fmt.Errorf("resubscribing order profit: %w", err)
+	}
+	return nil
+}
+
+// addQuotes records quote subscriptions
+func (t *subscriptionTracker) addQuotes(symbols []string, interval int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.set.Quotes == nil {
+		t.set.Quotes = make(map[string]int)
+	}
+	for _, symbol := range symbols {
+		t.set.Quotes[symbol] = interval
+	}
+}
+
+// removeQuotes forgets quote subscriptions
+func (t *subscriptionTracker) removeQuotes(symbols []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, symbol := range symbols {
+		delete(t.set.Quotes, symbol)
+	}
+}
+
+// addOHLC records an OHLC subscription
+func (t *subscriptionTracker) addOHLC(key OHLCKey, interval int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.set.OHLC == nil {
+		t.set.OHLC = make(map[OHLCKey]int)
+	}
+	t.set.OHLC[key] = interval
+}
+
+// removeOHLC forgets OHLC subscriptions; empty fields match any value
+func (t *subscriptionTracker) removeOHLC(symbol string, timeframe int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range t.set.OHLC {
+		if (symbol == "" || key.Symbol == symbol) && (timeframe == 0 || key.Timeframe == timeframe) {
+			delete(t.set.OHLC, key)
+		}
+	}
+}
+
+// setOrderProfit records the order profit subscription
+func (t *subscriptionTracker) setOrderProfit(interval int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.set.OrderProfit = true
+	t.set.OrderProfitInterval = interval
+}
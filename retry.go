@@ -0,0 +1,136 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"time"
+)
+
+// RetryPolicy controls automatic retries of failed requests
+type RetryPolicy struct {
+	MaxAttempts    int                  // Total attempts including the first one
+	InitialBackoff time.Duration        // Delay before the first retry
+	MaxBackoff     time.Duration        // Upper bound for the delay
+	Multiplier     float64              // Backoff growth factor per attempt
+	Jitter         float64              // Random fraction (0..1) subtracted from each delay
+	Retryable      func(err error) bool // Classifier, defaults to IsRetryable
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+// nonIdempotentEndpoints are never retried unless the caller opts in
+var nonIdempotentEndpoints = map[string]bool{
+	"/OrderSend":    true,
+	"/OrderClose":   true,
+	"/OrderModify":  true,
+	"/Connect":      true,
+	"/ConnectEx":    true,
+	"/ConnectProxy": true,
+	"/Disconnect":   true,
+}
+
+type retryTradingKey struct{}
+
+// noRetryKey marks a context whose calls bypass the retry policy
+type noRetryKey struct{}
+
+// WithTradingRetry returns a context that allows the client retry policy to
+// be applied to order and connection calls made with it
+func WithTradingRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryTradingKey{}, true)
+}
+
+// IsRetryable reports whether err is a transient network or server error
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == 429
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// retryable reports whether a failed attempt should be retried
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the delay before the given retry (1-based)
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			delay = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// canRetry reports whether the policy applies to the endpoint
+func (c *Client) canRetry(ctx context.Context, endpoint string) bool {
+	if c.RetryPolicy == nil || c.RetryPolicy.MaxAttempts <= 1 {
+		return false
+	}
+	if disabled, _ := ctx.Value(noRetryKey{}).(bool); disabled {
+		return false
+	}
+	if nonIdempotentEndpoints[endpoint] {
+		return tradingRetryAllowed(ctx)
+	}
+	return true
+}
+
+// tradingRetryAllowed reports whether the caller opted in with
+// WithTradingRetry
+func tradingRetryAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(retryTradingKey{}).(bool)
+	return allowed
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
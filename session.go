@@ -0,0 +1,215 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ConnectionState represents the state of a managed session
+type ConnectionState string
+
+const (
+	ConnectionDisconnected ConnectionState = "Disconnected"
+	ConnectionConnecting   ConnectionState = "Connecting"
+	ConnectionConnected    ConnectionState = "Connected"
+	ConnectionReconnecting ConnectionState = "Reconnecting"
+)
+
+// CredentialProvider establishes a session on a client with credentials
+// obtained at call time, so they need not be kept in memory
+type CredentialProvider interface {
+	Connect(ctx context.Context, c *Client) (string, error)
+}
+
+// ConnectCredentials loads ConnectRequest credentials on demand
+type ConnectCredentials func(ctx context.Context) (ConnectRequest, error)
+
+// Connect implements CredentialProvider
+func (f ConnectCredentials) Connect(ctx context.Context, c *Client) (string, error) {
+	req, err := f(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading credentials: %w", err)
+	}
+	return c.Connect(ctx, req)
+}
+
+// ConnectExCredentials loads ConnectExRequest credentials on demand
+type ConnectExCredentials func(ctx context.Context) (ConnectExRequest, error)
+
+// Connect implements CredentialProvider
+func (f ConnectExCredentials) Connect(ctx context.Context, c *Client) (string, error) {
+	req, err := f(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading credentials: %w", err)
+	}
+	return c.ConnectEx(ctx, req)
+}
+
+// ConnectProxyCredentials loads ConnectProxyRequest credentials on demand
+type ConnectProxyCredentials func(ctx context.Context) (ConnectProxyRequest, error)
+
+// Connect implements CredentialProvider
+func (f ConnectProxyCredentials) Connect(ctx context.Context, c *Client) (string, error) {
+	req, err := f(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading credentials: %w", err)
+	}
+	return c.ConnectProxy(ctx, req)
+}
+
+// sessionEndpoints are never transparently reconnected
+var sessionEndpoints = map[string]bool{
+	"/Connect":      true,
+	"/ConnectEx":    true,
+	"/ConnectProxy": true,
+	"/Disconnect":   true,
+}
+
+type sessionBypassKey struct{}
+
+// SessionManager keeps a client session alive by reconnecting once and
+// retrying the original call when the server reports a lost session
+type SessionManager struct {
+	client      *Client
+	credentials CredentialProvider
+
+	mu          sync.Mutex // serializes connects
+	stateMu     sync.Mutex
+	state       ConnectionState
+	listeners   []func(from, to ConnectionState)
+	transitions []stateTransition // pending listener notifications
+}
+
+// stateTransition is a state change not yet passed to listeners
+type stateTransition struct {
+	from, to ConnectionState
+}
+
+// NewSessionManager creates a session manager and attaches it to the client,
+// so requests failing with ErrNotConnected or ErrInvalidToken are retried
+// after a transparent reconnect. Order calls reconnect but return the
+// original error unless the context comes from WithTradingRetry.
+func NewSessionManager(c *Client, credentials CredentialProvider) *SessionManager {
+	s := &SessionManager{
+		client:      c,
+		credentials: credentials,
+		state:       ConnectionDisconnected,
+	}
+	c.mu.Lock()
+	c.session = s
+	c.mu.Unlock()
+	return s
+}
+
+// State returns the current connection state
+func (s *SessionManager) State() ConnectionState {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	return s.state
+}
+
+// OnStateChange registers a callback invoked on every state transition.
+// Callbacks run after the session lock is released and may use the client.
+func (s *SessionManager) OnStateChange(callback func(from, to ConnectionState)) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.listeners = append(s.listeners, callback)
+}
+
+// Connect establishes the session using the credential provider
+func (s *SessionManager) Connect(ctx context.Context) error {
+	defer s.notify()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connect(ctx, ConnectionConnecting)
+}
+
+// Reconnect re-establishes the session unconditionally
+func (s *SessionManager) Reconnect(ctx context.Context) error {
+	defer s.notify()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connect(ctx, ConnectionReconnecting)
+}
+
+// Disconnect closes the session
+func (s *SessionManager) Disconnect(ctx context.Context) error {
+	defer s.notify()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.client.Disconnect(context.WithValue(ctx, sessionBypassKey{}, true))
+	s.setState(ConnectionDisconnected)
+	return err
+}
+
+// connect runs the credential provider; callers must hold s.mu
+func (s *SessionManager) connect(ctx context.Context, pending ConnectionState) error {
+	s.setState(pending)
+	if _, err := s.credentials.Connect(context.WithValue(ctx, sessionBypassKey{}, true), s.client); err != nil {
+		s.setState(ConnectionDisconnected)
+		return err
+	}
+	s.setState(ConnectionConnected)
+
+	// A new session may have lost the server-side subscriptions
+	if err := s.client.Resubscribe(context.WithValue(ctx, sessionBypassKey{}, true)); err != nil && s.client.Logger != nil {
+		s.client.Logger.LogAttrs(ctx, s.client.ErrorLogLevel, "mt5api resubscribe failed",
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// recover reconnects after a lost session unless another caller already
+// replaced the stale token
+func (s *SessionManager) recover(ctx context.Context, staleToken string) error {
+	defer s.notify()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client.Token() != staleToken && s.State() == ConnectionConnected {
+		return nil
+	}
+	return s.connect(ctx, ConnectionReconnecting)
+}
+
+// shouldRecover reports whether a failed request warrants a reconnect
+func (s *SessionManager) shouldRecover(ctx context.Context, endpoint string, err error) bool {
+	if sessionEndpoints[endpoint] || ctx.Err() != nil {
+		return false
+	}
+	if bypass, _ := ctx.Value(sessionBypassKey{}).(bool); bypass {
+		return false
+	}
+	return errors.Is(err, ErrNotConnected) || errors.Is(err, ErrInvalidToken)
+}
+
+// setState updates the state and queues a notification on change; callers
+// run notify once s.mu is released
+func (s *SessionManager) setState(state ConnectionState) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	if s.state != state {
+		s.transitions = append(s.transitions, stateTransition{from: s.state, to: state})
+		s.state = state
+	}
+}
+
+// notify passes queued state transitions to the listeners
+func (s *SessionManager) notify() {
+	s.stateMu.Lock()
+	transitions := s.transitions
+	s.transitions = nil
+	listeners := append([]func(from, to ConnectionState){}, s.listeners...)
+	s.stateMu.Unlock()
+
+	for _, transition := range transitions {
+		for _, listener := range listeners {
+			listener(transition.from, transition.to)
+		}
+	}
+}
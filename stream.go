@@ -0,0 +1,241 @@
+package mt5api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// StreamState represents the state of a WebSocket subscription
+type StreamState string
+
+const (
+	StreamConnecting   StreamState = "Connecting"
+	StreamConnected    StreamState = "Connected"
+	StreamDisconnected StreamState = "Disconnected"
+	StreamClosed       StreamState = "Closed"
+)
+
+// errRestartRequested is reported when SubscribeOptions.Restart fires
+var errRestartRequested = errors.New("websocket restart requested")
+
+// Decoder decodes a raw WebSocket message. It returns ok false for messages
+// of other types, which are skipped.
+type Decoder[T any] func(message []byte) (value T, ok bool, err error)
+
+// SubscribeOptions configures a WebSocket subscription
+type SubscribeOptions struct {
+	InitialBackoff time.Duration // Delay before reconnecting, default 1s
+	MaxBackoff     time.Duration // Upper bound for the reconnect delay, default 5m
+	PingInterval   time.Duration // Interval between pings, default 30s
+	ReadDeadline   time.Duration // Maximum silence before reconnecting, default 3 ping intervals
+
+	OnError func(err error)         // Called on dial, read and decode errors
+	OnState func(state StreamState) // Called on every state change
+
+	// Restart forces a reconnect whenever a value is received. Set it on the
+	// options of a single subscription; Client.SubscribeOptions is shared by
+	// every SocketOn* stream.
+	Restart <-chan struct{}
+}
+
+// withDefaults fills unset options
+func (o SubscribeOptions) withDefaults() SubscribeOptions {
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Minute
+	}
+	if o.PingInterval <= 0 {
+		o.PingInterval = 30 * time.Second
+	}
+	if o.ReadDeadline <= 0 {
+		o.ReadDeadline = 3 * o.PingInterval
+	}
+	return o
+}
+
+// EnvelopeDecoder decodes messages wrapped as {"type": ..., "data": ...}
+// with the given type. Unwrapped messages are accepted when bare is non-nil
+// and reports true for the decoded value.
+func EnvelopeDecoder[T any](messageType string, bare func(T) bool) Decoder[T] {
+	return func(message []byte) (T, bool, error) {
+		var value T
+
+		var envelope struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(message, &envelope); err != nil {
+			return value, false, fmt.Errorf("decoding message: %w", err)
+		}
+
+		if envelope.Type != "" {
+			if envelope.Type != messageType {
+				return value, false, nil
+			}
+			if err := json.Unmarshal(envelope.Data, &value); err != nil {
+				return value, false, fmt.Errorf("decoding %s: %w", messageType, err)
+			}
+			return value, true, nil
+		}
+
+		if bare == nil {
+			return value, false, nil
+		}
+		if err := json.Unmarshal(message, &value); err != nil {
+			return value, false, fmt.Errorf("decoding %s: %w", messageType, err)
+		}
+		return value, bare(value), nil
+	}
+}
+
+// Subscribe connects to a WebSocket endpoint and passes every decoded
+// message to handler on the read goroutine. It reconnects with exponential
+// backoff and blocks until ctx is done.
+func Subscribe[T any](ctx context.Context, c *Client, endpoint string, decode Decoder[T], handler func(T), opts SubscribeOptions) {
+	opts = opts.withDefaults()
+	s := &subscription[T]{
+		client:   c,
+		endpoint: endpoint,
+		decode:   decode,
+		handler:  handler,
+		opts:     opts,
+	}
+	s.run(ctx)
+}
+
+// subscription runs a reconnecting WebSocket stream
+type subscription[T any] struct {
+	client   *Client
+	endpoint string
+	decode   Decoder[T]
+	handler  func(T)
+	opts     SubscribeOptions
+}
+
+// run connects and reconnects until ctx is done
+func (s *subscription[T]) run(ctx context.Context) {
+	defer s.setState(StreamClosed)
+
+	backoff := s.opts.InitialBackoff
+	connected := false
+
+	for ctx.Err() == nil {
+		s.setState(StreamConnecting)
+		wsConn, err := s.client.ConnectWebSocket(ctx, s.endpoint)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.reportError(err)
+			s.setState(StreamDisconnected)
+			if sleepContext(ctx, backoff) != nil {
+				return
+			}
+			backoff = min(backoff*2, s.opts.MaxBackoff)
+			continue
+		}
+
+		if connected {
+			s.client.observeReconnect(s.endpoint)
+			// Subscriptions may have been dropped while disconnected
+			s.reportError(s.client.resubscribeEndpoint(ctx, s.endpoint))
+		}
+		connected = true
+		backoff = s.opts.InitialBackoff
+		s.setState(StreamConnected)
+
+		err = s.serve(ctx, wsConn)
+		if ctx.Err() != nil {
+			return
+		}
+		s.reportError(err)
+		s.setState(StreamDisconnected)
+		if sleepContext(ctx, backoff) != nil {
+			return
+		}
+	}
+}
+
+// serve reads from one connection until it fails or ctx is done
+func (s *subscription[T]) serve(ctx context.Context, wsConn *WebSocketConnection) error {
+	conn := wsConn.conn
+	defer conn.Close()
+
+	extendDeadline := func() {
+		conn.SetReadDeadline(time.Now().Add(s.opts.ReadDeadline))
+	}
+	extendDeadline()
+	conn.SetPongHandler(func(string) error {
+		extendDeadline()
+		return nil
+	})
+
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- fmt.Errorf("websocket read error: %w", err)
+				return
+			}
+			extendDeadline()
+			s.client.observeMessage(s.endpoint)
+
+			value, ok, err := s.decode(message)
+			if err != nil {
+				s.reportError(fmt.Errorf("%s: %w", s.endpoint, err))
+				continue
+			}
+			if ok {
+				s.handler(value)
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(s.opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Closing the connection unblocks the reader
+			conn.Close()
+			<-readErr
+			return ctx.Err()
+		case <-ticker.C:
+			deadline := time.Now().Add(s.opts.PingInterval)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				conn.Close()
+				<-readErr
+				return fmt.Errorf("websocket ping error: %w", err)
+			}
+		case <-s.opts.Restart:
+			conn.Close()
+			<-readErr
+			return errRestartRequested
+		case err := <-readErr:
+			return err
+		}
+	}
+}
+
+// reportError passes err to the OnError callback
+func (s *subscription[T]) reportError(err error) {
+	if err != nil && s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+// setState passes state to the OnState callback
+func (s *subscription[T]) setState(state StreamState) {
+	if s.opts.OnState != nil {
+		s.opts.OnState(state)
+	}
+}
@@ -20,6 +20,7 @@ func (c *Client) Subscribe(ctx context.Context, symbol string, interval int) (st
 		return "", err
 	}
 
+	c.subscriptions.addQuotes([]string{symbol}, interval)
 	return string(body), nil
 }
 
@@ -38,6 +39,7 @@ func (c *Client) SubscribeMany(ctx context.Context, symbols []string, interval i
 		return "", err
 	}
 
+	c.subscriptions.addQuotes(symbols, interval)
 	return string(body), nil
 }
 
@@ -51,6 +53,7 @@ func (c *Client) UnSubscribe(ctx context.Context, symbol string) (string, error)
 		return "", err
 	}
 
+	c.subscriptions.removeQuotes([]string{symbol})
 	return string(body), nil
 }
 
@@ -66,6 +69,7 @@ func (c *Client) UnSubscribeMany(ctx context.Context, symbols []string) (string,
 		return "", err
 	}
 
+	c.subscriptions.removeQuotes(symbols)
 	return string(body), nil
 }
 
@@ -81,6 +85,8 @@ func (c *Client) SubscribeOrderProfit(ctx context.Context, interval int) ([]Orde
 		return nil, err
 	}
 
+	c.subscriptions.setOrderProfit(interval)
+
 	var orders []Order
 	if err := json.Unmarshal(body, &orders); err != nil {
 		return nil, err
@@ -107,6 +113,7 @@ func (c *Client) SubscribeOHLC(ctx context.Context, symbol string, timeframe, in
 		return "", err
 	}
 
+	c.subscriptions.addOHLC(OHLCKey{Symbol: symbol, Timeframe: timeframe}, interval)
 	return string(body), nil
 }
 
@@ -125,5 +132,6 @@ func (c *Client) UnsubscribeOHLC(ctx context.Context, symbol string, timeframe i
 		return "", err
 	}
 
+	c.subscriptions.removeOHLC(symbol, timeframe)
 	return string(body), nil
 }
@@ -0,0 +1,301 @@
+package mt5api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// VolumeLimits describes the allowed order volume of a symbol in lots
+type VolumeLimits struct {
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+// FieldChange describes a changed symbol parameter
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// SymbolDiff describes broker changes to the parameters of a symbol
+type SymbolDiff struct {
+	Symbol  string
+	Changes []FieldChange
+}
+
+// SymbolRegistry caches symbol metadata. Symbols are loaded once and
+// refreshed after the TTL; SymbolParams are fetched lazily per symbol and
+// refetched when SymbolInfo.UpdateTime changes.
+type SymbolRegistry struct {
+	client *Client
+	ttl    time.Duration
+
+	mu        sync.RWMutex
+	symbols   map[string]SymbolInfo
+	loaded    time.Time
+	params    map[string]cachedParams
+	listeners []func(SymbolDiff)
+}
+
+// cachedParams is a SymbolParams with the time it was fetched
+type cachedParams struct {
+	params  SymbolParams
+	fetched time.Time
+}
+
+// NewSymbolRegistry creates a registry; cached data older than ttl is
+// refreshed on access (default 1h)
+func NewSymbolRegistry(c *Client, ttl time.Duration) *SymbolRegistry {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &SymbolRegistry{
+		client: c,
+		ttl:    ttl,
+		params: make(map[string]cachedParams),
+	}
+}
+
+// OnChange registers a callback invoked when refetched parameters differ
+// in swaps, margins, volumes or contract specification
+func (r *SymbolRegistry) OnChange(callback func(SymbolDiff)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, callback)
+}
+
+// Refresh reloads the symbol list, drops cached parameters of removed
+// symbols and refetches those whose UpdateTime changed. Failed refetches do
+// not stop the others and are returned joined.
+func (r *SymbolRegistry) Refresh(ctx context.Context) error {
+	symbols, err := r.client.Symbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.symbols = symbols
+	r.loaded = time.Now()
+	var changed []string
+	for symbol, cached := range r.params {
+		info, ok := symbols[symbol]
+		if !ok {
+			// The broker removed the symbol
+			delete(r.params, symbol)
+		} else if info.UpdateTime != cached.params.SymbolInfo.UpdateTime {
+			changed = append(changed, symbol)
+		}
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for _, symbol := range changed {
+		if _, err := r.fetchParams(ctx, symbol); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", symbol, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Symbols returns information about all symbols
+func (r *SymbolRegistry) Symbols(ctx context.Context) (map[string]SymbolInfo, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbols := make(map[string]SymbolInfo, len(r.symbols))
+	for symbol, info := range r.symbols {
+		symbols[symbol] = info
+	}
+	return symbols, nil
+}
+
+// Info returns information about a symbol
+func (r *SymbolRegistry) Info(ctx context.Context, symbol string) (SymbolInfo, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return SymbolInfo{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.symbols[symbol]
+	if !ok {
+		return SymbolInfo{}, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	return info, nil
+}
+
+// Params returns the full parameters of a symbol and its group
+func (r *SymbolRegistry) Params(ctx context.Context, symbol string) (*SymbolParams, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	if params, ok := r.Cached(symbol); ok {
+		return params, nil
+	}
+	return r.fetchParams(ctx, symbol)
+}
+
+// Cached returns the cached parameters of a symbol without fetching them;
+// ok is false when they are missing or stale
+func (r *SymbolRegistry) Cached(symbol string) (params *SymbolParams, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cached, ok := r.params[symbol]
+	if !ok || time.Since(cached.fetched) >= r.ttl {
+		return nil, false
+	}
+	if info, known := r.symbols[symbol]; known && info.UpdateTime != cached.params.SymbolInfo.UpdateTime {
+		return nil, false
+	}
+	copied := cached.params
+	return &copied, true
+}
+
+// Digits returns the number of price digits of a symbol
+func (r *SymbolRegistry) Digits(ctx context.Context, symbol string) (int32, error) {
+	info, err := r.Info(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return info.Digits, nil
+}
+
+// Point returns the point size of a symbol
+func (r *SymbolRegistry) Point(ctx context.Context, symbol string) (float64, error) {
+	info, err := r.Info(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return symbolPoint(info), nil
+}
+
+// ContractSize returns the contract size of a symbol
+func (r *SymbolRegistry) ContractSize(ctx context.Context, symbol string) (float64, error) {
+	info, err := r.Info(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return info.ContractSize, nil
+}
+
+// VolumeLimits returns the allowed volume of a symbol in lots
+func (r *SymbolRegistry) VolumeLimits(ctx context.Context, symbol string) (VolumeLimits, error) {
+	params, err := r.Params(ctx, symbol)
+	if err != nil {
+		return VolumeLimits{}, err
+	}
+	return VolumeLimits{
+		Min:  params.SymbolGroup.MinLots,
+		Max:  params.SymbolGroup.MaxLots,
+		Step: params.SymbolGroup.LotsStep,
+	}, nil
+}
+
+// symbolParams returns symbol parameters from the registry when set
+func (c *Client) symbolParams(ctx context.Context, symbol string) (*SymbolParams, error) {
+	if c.Registry != nil {
+		return c.Registry.Params(ctx, symbol)
+	}
+	return c.SymbolParams(ctx, symbol)
+}
+
+// symbolExists reports whether the symbol list contains symbol, using the
+// registry when set
+func (c *Client) symbolExists(ctx context.Context, symbol string) (bool, error) {
+	if c.Registry != nil {
+		_, err := c.Registry.Info(ctx, symbol)
+		if errors.Is(err, ErrUnknownSymbol) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	symbols, err := c.Symbols(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ok := symbols[symbol]
+	return ok, nil
+}
+
+// ensureLoaded loads the symbol list when missing or expired
+func (r *SymbolRegistry) ensureLoaded(ctx context.Context) error {
+	r.mu.RLock()
+	fresh := r.symbols != nil && time.Since(r.loaded) < r.ttl
+	r.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+	return r.Refresh(ctx)
+}
+
+// fetchParams fetches and caches the parameters of a symbol, notifying
+// listeners of changes
+func (r *SymbolRegistry) fetchParams(ctx context.Context, symbol string) (*SymbolParams, error) {
+	params, err := r.client.SymbolParams(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	previous, hadPrevious := r.params[symbol]
+	r.params[symbol] = cachedParams{params: *params, fetched: time.Now()}
+	listeners := append([]func(SymbolDiff){}, r.listeners...)
+	r.mu.Unlock()
+
+	if hadPrevious {
+		if changes := diffSymbolParams(previous.params, *params); len(changes) > 0 {
+			diff := SymbolDiff{Symbol: symbol, Changes: changes}
+			for _, listener := range listeners {
+				listener(diff)
+			}
+		}
+	}
+	return params, nil
+}
+
+// diffSymbolParams compares the trading parameters of two snapshots
+func diffSymbolParams(old, new SymbolParams) []FieldChange {
+	var changes []FieldChange
+	compare := func(field string, a, b any) {
+		oldValue, newValue := fmt.Sprint(a), fmt.Sprint(b)
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	og, ng := old.SymbolGroup, new.SymbolGroup
+	compare("SwapType", og.SwapType, ng.SwapType)
+	compare("SwapLong", og.SwapLong, ng.SwapLong)
+	compare("SwapShort", og.SwapShort, ng.SwapShort)
+	compare("ThreeDaysSwap", og.ThreeDaysSwap, ng.ThreeDaysSwap)
+	compare("InitialMargin", og.InitialMargin, ng.InitialMargin)
+	compare("MaintenanceMargin", og.MaintenanceMargin, ng.MaintenanceMargin)
+	compare("HedgedMargin", og.HedgedMargin, ng.HedgedMargin)
+	compare("MinLots", og.MinLots, ng.MinLots)
+	compare("MaxLots", og.MaxLots, ng.MaxLots)
+	compare("LotsStep", og.LotsStep, ng.LotsStep)
+	compare("TradeMode", og.TradeMode, ng.TradeMode)
+
+	oi, ni := old.SymbolInfo, new.SymbolInfo
+	compare("Digits", oi.Digits, ni.Digits)
+	compare("ContractSize", oi.ContractSize, ni.ContractSize)
+	compare("TickSize", oi.TickSize, ni.TickSize)
+	return changes
+}
+
+// symbolPoint returns the point size, derived from digits when missing
+func symbolPoint(info SymbolInfo) float64 {
+	if info.Points > 0 {
+		return info.Points
+	}
+	return math.Pow10(-int(info.Digits))
+}
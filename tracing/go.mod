@@ -0,0 +1,11 @@
+module github.com/ditthkr/mt5api/tracing
+
+go 1.23.7
+
+require (
+	github.com/ditthkr/mt5api v0.0.0-20261017025241-cdeb5fb417ec
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require github.com/gorilla/websocket v1.5.3 // indirect
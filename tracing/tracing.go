@@ -0,0 +1,258 @@
+// Package tracing adds OpenTelemetry spans to MT5 API calls and follows
+// orders from submission to their confirming OrderUpdate.
+//
+// It is a separate module so the core client does not depend on
+// OpenTelemetry. To develop both modules together, create a workspace in
+// the repository root with "go work init . ./tracing".
+package tracing
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ditthkr/mt5api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ditthkr/mt5api/tracing"
+
+// Interceptor returns an interceptor that creates a client span per call
+func Interceptor(tp trace.TracerProvider) mt5api.Interceptor {
+	tracer := tp.Tracer(instrumentationName)
+
+	return func(next mt5api.RoundTrip) mt5api.RoundTrip {
+		return func(ctx context.Context, call *mt5api.Call) (*mt5api.CallResult, error) {
+			attrs := []attribute.KeyValue{
+				attribute.String("mt5.endpoint", call.Endpoint),
+				attribute.String("http.request.method", call.Method),
+			}
+			if symbol := call.Params.Get("symbol"); symbol != "" {
+				attrs = append(attrs, attribute.String("mt5.symbol", symbol))
+			}
+			if ticket, err := strconv.ParseInt(call.Params.Get("ticket"), 10, 64); err == nil {
+				attrs = append(attrs, attribute.Int64("mt5.ticket", ticket))
+			}
+			if operation := call.Params.Get("operation"); operation != "" {
+				attrs = append(attrs, attribute.String("mt5.operation", operation))
+			}
+
+			ctx, span := tracer.Start(ctx, "mt5api "+call.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			result, err := next(ctx, call)
+			if result != nil {
+				span.SetAttributes(attribute.Int("http.response.status_code", result.StatusCode))
+			}
+			if err != nil {
+				recordError(span, err)
+			}
+			return result, err
+		}
+	}
+}
+
+// OrderTracker traces order submissions until a matching OrderUpdate
+// confirms them. Feed it from Client.SocketOnOrderUpdate.
+type OrderTracker struct {
+	// Timeout bounds how long an order span waits for its confirming update
+	Timeout time.Duration
+
+	tracer trace.Tracer
+
+	mu      sync.Mutex
+	pending map[int64]*pendingOrder
+	settled map[int64]time.Time // Tickets confirmed before their span registered
+}
+
+// pendingOrder is an order span awaiting confirmation
+type pendingOrder struct {
+	span    trace.Span
+	timer   *time.Timer
+	closing bool // Waiting for a closing deal rather than an order fill
+}
+
+// NewOrderTracker creates an order tracker
+func NewOrderTracker(tp trace.TracerProvider) *OrderTracker {
+	return &OrderTracker{
+		Timeout: time.Minute,
+		tracer:  tp.Tracer(instrumentationName),
+		pending: make(map[int64]*pendingOrder),
+		settled: make(map[int64]time.Time),
+	}
+}
+
+// OrderSend sends an order inside a span that ends when the order is
+// confirmed on the OrderUpdate stream
+func (t *OrderTracker) OrderSend(ctx context.Context, c *mt5api.Client, req mt5api.OrderSendRequest) (*mt5api.Order, error) {
+	ctx, span := t.tracer.Start(ctx, "mt5api order send",
+		trace.WithAttributes(
+			attribute.String("mt5.symbol", req.Symbol),
+			attribute.String("mt5.operation", string(req.Operation)),
+			attribute.Float64("mt5.volume", req.Volume),
+		),
+	)
+
+	order, err := c.OrderSend(ctx, req)
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("mt5.ticket", order.Ticket))
+	span.AddEvent("order accepted", trace.WithAttributes(
+		attribute.String("mt5.state", string(order.State)),
+		attribute.Float64("mt5.price", order.OpenPrice),
+	))
+	if isFinal(order.State) && order.State != mt5api.StateFilled {
+		span.End()
+		return order, nil
+	}
+	t.track(order.Ticket, span, false)
+	return order, nil
+}
+
+// OrderClose closes an order inside a span that ends when the closing deal
+// is confirmed on the OrderUpdate stream
+func (t *OrderTracker) OrderClose(ctx context.Context, c *mt5api.Client, req mt5api.OrderCloseRequest) (*mt5api.Order, error) {
+	ctx, span := t.tracer.Start(ctx, "mt5api order close",
+		trace.WithAttributes(
+			attribute.Int64("mt5.ticket", req.Ticket),
+			attribute.Float64("mt5.volume", req.Lots),
+		),
+	)
+
+	order, err := c.OrderClose(ctx, req)
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	span.AddEvent("close accepted", trace.WithAttributes(
+		attribute.Float64("mt5.price", order.ClosePrice),
+	))
+	t.track(req.Ticket, span, true)
+	return order, nil
+}
+
+// HandleOrderUpdate records an OrderUpdate on the matching order span
+func (t *OrderTracker) HandleOrderUpdate(summary *mt5api.OrderUpdateSummary) {
+	update := summary.Update
+	state := update.OrderInternal.State
+	if state == "" {
+		state = update.Order.State
+	}
+	dealDone := update.Deal.TicketNumber != 0
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expireSettled()
+
+	for _, ticket := range updateTickets(update) {
+		p, ok := t.pending[ticket]
+		if !ok {
+			if isFinal(state) || dealDone {
+				t.settled[ticket] = time.Now()
+			}
+			continue
+		}
+
+		p.span.AddEvent("order update", trace.WithAttributes(
+			attribute.String("mt5.update_type", update.Type),
+			attribute.String("mt5.state", string(state)),
+			attribute.Float64("mt5.price", update.Deal.Price),
+			attribute.Float64("mt5.volume", update.Deal.Lots),
+		))
+
+		if (p.closing && dealDone) || (!p.closing && isFinal(state)) {
+			if state == mt5api.StateRejected {
+				p.span.SetStatus(codes.Error, "order rejected")
+			}
+			p.timer.Stop()
+			p.span.End()
+			delete(t.pending, ticket)
+		}
+	}
+}
+
+// track registers a span awaiting confirmation of ticket
+func (t *OrderTracker) track(ticket int64, span trace.Span, closing bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.settled[ticket]; ok {
+		delete(t.settled, ticket)
+		span.AddEvent("order update")
+		span.End()
+		return
+	}
+
+	p := &pendingOrder{span: span, closing: closing}
+	p.timer = time.AfterFunc(t.Timeout, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.pending[ticket] != p {
+			return
+		}
+		delete(t.pending, ticket)
+		span.AddEvent("confirmation timeout")
+		span.End()
+	})
+	t.pending[ticket] = p
+}
+
+// expireSettled drops stale early confirmations; callers must hold t.mu
+func (t *OrderTracker) expireSettled() {
+	cutoff := time.Now().Add(-t.Timeout)
+	for ticket, at := range t.settled {
+		if at.Before(cutoff) {
+			delete(t.settled, ticket)
+		}
+	}
+}
+
+// updateTickets returns the tickets an update refers to
+func updateTickets(update mt5api.OrderUpdate) []int64 {
+	var tickets []int64
+	for _, ticket := range []int64{
+		update.Order.Ticket,
+		update.OrderInternal.TicketNumber,
+		update.Deal.OrderTicket,
+		update.Deal.PositionTicket,
+		update.Trans.TicketNumber,
+	} {
+		if ticket != 0 && !slices.Contains(tickets, ticket) {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets
+}
+
+// isFinal reports whether an order state is terminal
+func isFinal(state mt5api.OrderState) bool {
+	switch state {
+	case mt5api.StateFilled, mt5api.StateRejected, mt5api.StateCancelled, mt5api.StateExpired:
+		return true
+	}
+	return false
+}
+
+// recordError marks the span as failed with the API error code
+func recordError(span trace.Span, err error) {
+	var apiErr *mt5api.APIError
+	if errors.As(err, &apiErr) && apiErr.Code != "" {
+		span.SetAttributes(attribute.String("mt5.error_code", apiErr.Code))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
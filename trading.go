@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // OrderSendRequest represents order send parameters
@@ -20,6 +21,15 @@ type OrderSendRequest struct {
 	ExpertId       int64      `json:"expertId,omitempty"`
 	StopLimitPrice float64    `json:"stopLimitPrice,omitempty"`
 	PlacedType     PlacedType `json:"placedType,omitempty"`
+
+	// FillPolicy is selected from the policies the symbol allows when empty;
+	// the server default applies when the symbol cannot be loaded
+	FillPolicy FillPolicy `json:"fillPolicy,omitempty"`
+
+	// Expiration is required for ExpirationSpecified and ExpirationSpecifiedDay
+	// and is sent in the server timezone
+	ExpirationType ExpirationType `json:"expirationType,omitempty"`
+	Expiration     time.Time      `json:"expiration,omitempty"`
 }
 
 // OrderModifyRequest represents order modify parameters
@@ -29,6 +39,11 @@ type OrderModifyRequest struct {
 	TakeProfit float64 `json:"takeprofit"`
 	Price      float64 `json:"price,omitempty"`
 	StopLimit  float64 `json:"stoplimit,omitempty"`
+
+	// Expiration is required for ExpirationSpecified and ExpirationSpecifiedDay
+	// and is sent in the server timezone
+	ExpirationType ExpirationType `json:"expirationType,omitempty"`
+	Expiration     time.Time      `json:"expiration,omitempty"`
 }
 
 // OrderCloseRequest represents order close parameters
@@ -41,6 +56,16 @@ type OrderCloseRequest struct {
 
 // OrderSend sends market or pending order
 func (c *Client) OrderSend(ctx context.Context, req OrderSendRequest) (*Order, error) {
+	if err := c.validateExpiration(ctx, req.Symbol, req.ExpirationType, req.Expiration); err != nil {
+		return nil, err
+	}
+	if err := c.validateOrder(ctx, req); err != nil {
+		return nil, err
+	}
+	if req.FillPolicy == "" {
+		req.FillPolicy = c.selectFillPolicy(ctx, req)
+	}
+
 	params := url.Values{}
 	params.Add("symbol", req.Symbol)
 	params.Add("operation", string(req.Operation))
@@ -70,6 +95,10 @@ func (c *Client) OrderSend(ctx context.Context, req OrderSendRequest) (*Order, e
 	if req.PlacedType != "" {
 		params.Add("placedType", string(req.PlacedType))
 	}
+	if req.FillPolicy != "" {
+		params.Add("fillPolicy", string(req.FillPolicy))
+	}
+	c.addExpiration(params, req.ExpirationType, req.Expiration)
 
 	body, err := c.doRequest(ctx, "GET", "/OrderSend", params)
 	if err != nil {
@@ -86,6 +115,19 @@ func (c *Client) OrderSend(ctx context.Context, req OrderSendRequest) (*Order, e
 
 // OrderModify modifies market or pending order
 func (c *Client) OrderModify(ctx context.Context, req OrderModifyRequest) (*Order, error) {
+	if err := checkExpiration(req.ExpirationType, req.Expiration); err != nil {
+		return nil, err
+	}
+	if req.ExpirationType != "" {
+		order, err := c.OpenedOrder(ctx, req.Ticket)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.validateExpiration(ctx, order.Symbol, req.ExpirationType, req.Expiration); err != nil {
+			return nil, err
+		}
+	}
+
 	params := url.Values{}
 	params.Add("ticket", strconv.FormatInt(req.Ticket, 10))
 	params.Add("stoploss", strconv.FormatFloat(req.StopLoss, 'f', -1, 64))
@@ -97,6 +139,7 @@ func (c *Client) OrderModify(ctx context.Context, req OrderModifyRequest) (*Orde
 	if req.StopLimit > 0 {
 		params.Add("stoplimit", strconv.FormatFloat(req.StopLimit, 'f', -1, 64))
 	}
+	c.addExpiration(params, req.ExpirationType, req.Expiration)
 
 	body, err := c.doRequest(ctx, "GET", "/OrderModify", params)
 	if err != nil {
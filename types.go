@@ -72,6 +72,15 @@ const (
 	ExpirationSpecifiedDay ExpirationType = "SpecifiedDay"
 )
 
+// FillPolicy Order filling policies
+type FillPolicy string
+
+const (
+	FillFOK    FillPolicy = "FillOrKill"
+	FillIOC    FillPolicy = "ImmediateOrCancel"
+	FillReturn FillPolicy = "FlashFill"
+)
+
 // AccountMethod Account related types
 type AccountMethod string
 
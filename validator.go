@@ -0,0 +1,292 @@
+package mt5api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Order flags of SymGroup.OrderFlags, the order types a symbol allows
+const (
+	OrderFlagMarket int32 = 1 << iota
+	OrderFlagLimit
+	OrderFlagStop
+	OrderFlagStopLimit
+	OrderFlagSL
+	OrderFlagTP
+	OrderFlagCloseBy
+)
+
+// OrderValidator checks an order before OrderSend sends it. It returns the
+// rules the order breaks, or an error when the checks could not run.
+type OrderValidator interface {
+	ValidateOrder(ctx context.Context, c *Client, req OrderSendRequest) ([]*ValidationError, error)
+}
+
+// PreTradeError is returned by OrderSend when the client Validator rejects
+// an order
+type PreTradeError struct {
+	Violations []*ValidationError
+}
+
+// Error implements the error interface
+func (e *PreTradeError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Error()
+	}
+	return "pre-trade validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the violations for errors.Is and errors.As
+func (e *PreTradeError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, violation := range e.Violations {
+		errs[i] = violation
+	}
+	return errs
+}
+
+// PreTradeValidator checks that the symbol exists and its session is open,
+// that the trade mode and order flags allow the order, that stop-limit
+// orders have a stop-limit price, that stops are on the correct side of the
+// price and that the required margin fits in the free margin
+type PreTradeValidator struct {
+	SkipSession bool // Do not call IsTradeSession
+	SkipMargin  bool // Do not compare RequiredMargin with the free margin
+}
+
+// ValidateOrder implements OrderValidator
+func (v PreTradeValidator) ValidateOrder(ctx context.Context, c *Client, req OrderSendRequest) ([]*ValidationError, error) {
+	exists, err := c.symbolExists(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []*ValidationError{{
+			Field:   "Symbol",
+			Message: fmt.Sprintf("unknown symbol %q", req.Symbol),
+			err:     ErrUnknownSymbol,
+		}}, nil
+	}
+
+	params, err := c.symbolParams(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	group := params.SymbolGroup
+
+	var violations []*ValidationError
+	if !v.SkipSession {
+		open, err := c.IsTradeSession(ctx, req.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		if !open {
+			violations = append(violations, &ValidationError{
+				Field:   "Symbol",
+				Message: fmt.Sprintf("trade session of %s is closed", req.Symbol),
+				err:     ErrMarketClosed,
+			})
+		}
+	}
+
+	if violation := checkTradeMode(group.TradeMode, req.Operation); violation != nil {
+		violations = append(violations, violation)
+	}
+	violations = append(violations, checkOrderFlags(group.OrderFlags, req)...)
+	if isStopLimitOrder(req.Operation) && req.StopLimitPrice <= 0 {
+		violations = append(violations, &ValidationError{
+			Field:   "StopLimitPrice",
+			Message: fmt.Sprintf("%s orders require a stop-limit price", req.Operation),
+		})
+	}
+
+	var quote *Quote
+	if isMarketOrder(req.Operation) && req.Price <= 0 {
+		if quote, err = c.GetQuote(ctx, req.Symbol, 0); err != nil {
+			return nil, err
+		}
+	}
+	violations = append(violations, checkStopSides(req, stopReference(req, quote))...)
+
+	if !v.SkipMargin && req.Volume > 0 {
+		violation, err := checkMargin(ctx, c, req, entryPrice(req, quote))
+		if err != nil {
+			return nil, err
+		}
+		if violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+// ValidateOrder checks an order with the client Validator, or with a
+// default PreTradeValidator when none is set, without sending it
+func (c *Client) ValidateOrder(ctx context.Context, req OrderSendRequest) ([]*ValidationError, error) {
+	validator := c.Validator
+	if validator == nil {
+		validator = PreTradeValidator{}
+	}
+	return validator.ValidateOrder(ctx, c, req)
+}
+
+// validateOrder runs the client Validator before an order is sent
+func (c *Client) validateOrder(ctx context.Context, req OrderSendRequest) error {
+	if c.Validator == nil {
+		return nil
+	}
+	violations, err := c.Validator.ValidateOrder(ctx, c, req)
+	if err != nil {
+		return fmt.Errorf("pre-trade validation: %w", err)
+	}
+	if len(violations) > 0 {
+		return &PreTradeError{Violations: violations}
+	}
+	return nil
+}
+
+// checkTradeMode reports a trade mode that does not allow the order
+func checkTradeMode(tradeMode string, operation OrderType) *ValidationError {
+	var allowed bool
+	switch strings.TrimPrefix(normalizeName(tradeMode), "SYMBOLTRADEMODE") {
+	case "DISABLED", "CLOSEONLY":
+		allowed = false
+	case "LONGONLY":
+		allowed = isBuyOrder(operation)
+	case "SHORTONLY":
+		allowed = !isBuyOrder(operation)
+	default:
+		allowed = true
+	}
+	if allowed {
+		return nil
+	}
+	return &ValidationError{
+		Field:   "Operation",
+		Message: fmt.Sprintf("trade mode %s does not allow %s orders", tradeMode, operation),
+	}
+}
+
+// checkOrderFlags reports order types and stops the symbol does not allow.
+// Zero flags are treated as unknown and allow everything.
+func checkOrderFlags(flags int32, req OrderSendRequest) []*ValidationError {
+	if flags == 0 {
+		return nil
+	}
+
+	var violations []*ValidationError
+	var required int32
+	switch req.Operation {
+	case OrderBuy, OrderSell:
+		required = OrderFlagMarket
+	case OrderBuyLimit, OrderSellLimit:
+		required = OrderFlagLimit
+	case OrderBuyStop, OrderSellStop:
+		required = OrderFlagStop
+	case OrderBuyStopLimit, OrderSellStopLimit:
+		required = OrderFlagStopLimit
+	case OrderCloseBy:
+		required = OrderFlagCloseBy
+	}
+	if required != 0 && flags&required == 0 {
+		violations = append(violations, &ValidationError{
+			Field:   "Operation",
+			Message: fmt.Sprintf("%s orders are not allowed for this symbol", req.Operation),
+		})
+	}
+	if req.StopLoss > 0 && flags&OrderFlagSL == 0 {
+		violations = append(violations, &ValidationError{
+			Field:   "StopLoss",
+			Message: "stop loss is not allowed for this symbol",
+			err:     ErrInvalidStops,
+		})
+	}
+	if req.TakeProfit > 0 && flags&OrderFlagTP == 0 {
+		violations = append(violations, &ValidationError{
+			Field:   "TakeProfit",
+			Message: "take profit is not allowed for this symbol",
+			err:     ErrInvalidStops,
+		})
+	}
+	return violations
+}
+
+// checkStopSides reports stops on the wrong side of the reference price
+func checkStopSides(req OrderSendRequest, reference float64) []*ValidationError {
+	if reference <= 0 {
+		return nil
+	}
+
+	buy := isBuyOrder(req.Operation)
+	var violations []*ValidationError
+	if req.StopLoss > 0 && (buy && req.StopLoss >= reference || !buy && req.StopLoss <= reference) {
+		violations = append(violations, &ValidationError{
+			Field:   "StopLoss",
+			Message: fmt.Sprintf("stop loss %g must be %s %g for %s orders", req.StopLoss, sideWord(buy, false), reference, req.Operation),
+			err:     ErrInvalidStops,
+		})
+	}
+	if req.TakeProfit > 0 && (buy && req.TakeProfit <= reference || !buy && req.TakeProfit >= reference) {
+		violations = append(violations, &ValidationError{
+			Field:   "TakeProfit",
+			Message: fmt.Sprintf("take profit %g must be %s %g for %s orders", req.TakeProfit, sideWord(buy, true), reference, req.Operation),
+			err:     ErrInvalidStops,
+		})
+	}
+	return violations
+}
+
+// checkMargin reports an order whose required margin exceeds the free margin
+func checkMargin(ctx context.Context, c *Client, req OrderSendRequest, price float64) (*ValidationError, error) {
+	required, err := c.RequiredMargin(ctx, req.Symbol, req.Volume, req.Operation, price)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := c.AccountSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if required <= summary.FreeMargin {
+		return nil, nil
+	}
+	return &ValidationError{
+		Field:   "Volume",
+		Message: fmt.Sprintf("required margin %g exceeds free margin %g", required, summary.FreeMargin),
+		err:     ErrNoMoney,
+	}, nil
+}
+
+// entryPrice returns the price an order opens at, zero if unknown
+func entryPrice(req OrderSendRequest, quote *Quote) float64 {
+	if quote != nil {
+		if isBuyOrder(req.Operation) {
+			return quote.Ask
+		}
+		return quote.Bid
+	}
+	return req.Price
+}
+
+// sideWord describes where a buy or sell stop must be placed
+func sideWord(buy, takeProfit bool) string {
+	if buy == takeProfit {
+		return "above"
+	}
+	return "below"
+}
+
+// isBuyOrder reports whether the order type buys
+func isBuyOrder(operation OrderType) bool {
+	switch operation {
+	case OrderBuy, OrderBuyLimit, OrderBuyStop, OrderBuyStopLimit:
+		return true
+	}
+	return false
+}
+
+// isStopLimitOrder reports whether the order type is a stop-limit order
+func isStopLimitOrder(operation OrderType) bool {
+	return operation == OrderBuyStopLimit || operation == OrderSellStopLimit
+}
@@ -0,0 +1,274 @@
+package mt5api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QuoteFreshness represents the kind of a watchdog event
+type QuoteFreshness string
+
+const (
+	QuoteStale     QuoteFreshness = "Stale"
+	QuoteRecovered QuoteFreshness = "Recovered"
+)
+
+// QuoteWatchdogEvent reports a symbol going stale or recovering
+type QuoteWatchdogEvent struct {
+	Symbol   string
+	Kind     QuoteFreshness
+	LastTick time.Time // Time of the last quote, zero if none was received
+	Age      time.Duration
+}
+
+// QuoteWatchdogConfig configures a QuoteWatchdog
+type QuoteWatchdogConfig struct {
+	MaxAge        time.Duration            // Default maximum quote age, default 1m
+	SymbolMaxAge  map[string]time.Duration // Per-symbol overrides of MaxAge
+	CheckInterval time.Duration            // Time between checks, default 5s
+
+	// CheckSession skips symbols whose trade session is closed
+	CheckSession    bool
+	SessionCacheTTL time.Duration // How long IsTradeSession results are reused, default 1m
+
+	// Resubscribe re-sends the quote subscription of a stale symbol
+	Resubscribe bool
+	// Reconnect signals Restart when a symbol goes stale
+	Reconnect bool
+}
+
+// QuoteWatchdog tracks per-symbol quote freshness and reports symbols whose
+// quotes stopped arriving while the stream stays open
+type QuoteWatchdog struct {
+	client  *Client
+	config  QuoteWatchdogConfig
+	events  chan QuoteWatchdogEvent
+	restart chan struct{}
+
+	mu       sync.Mutex
+	symbols  map[string]*quoteFreshness
+	started  time.Time
+	sessions map[string]sessionCheck
+}
+
+// quoteFreshness is the tracked state of one symbol
+type quoteFreshness struct {
+	lastTick   time.Time
+	stale      bool
+	subscribed bool // Added from the client subscriptions
+}
+
+// sessionCheck is a cached IsTradeSession result
+type sessionCheck struct {
+	open    bool
+	checked time.Time
+}
+
+// NewQuoteWatchdog creates a quote watchdog for the client
+func NewQuoteWatchdog(c *Client, config QuoteWatchdogConfig) *QuoteWatchdog {
+	if config.MaxAge <= 0 {
+		config.MaxAge = time.Minute
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = 5 * time.Second
+	}
+	if config.SessionCacheTTL <= 0 {
+		config.SessionCacheTTL = time.Minute
+	}
+
+	return &QuoteWatchdog{
+		client:   c,
+		config:   config,
+		events:   make(chan QuoteWatchdogEvent, 64),
+		restart:  make(chan struct{}, 1),
+		symbols:  make(map[string]*quoteFreshness),
+		started:  time.Now(),
+		sessions: make(map[string]sessionCheck),
+	}
+}
+
+// Events returns the channel of stale and recovered events. Events are
+// dropped when the channel buffer is full.
+func (w *QuoteWatchdog) Events() <-chan QuoteWatchdogEvent {
+	return w.events
+}
+
+// Restart returns a channel signalled when the quote stream should be
+// reconnected. Pass it as SubscribeOptions.Restart of the quote subscription
+// only; Client.SubscribeOptions is shared by every SocketOn* stream. Stream
+// does this.
+func (w *QuoteWatchdog) Restart() <-chan struct{} {
+	return w.restart
+}
+
+// Stream runs a quote stream that feeds the watchdog and is restarted on
+// Restart signals, passing every quote to callback when it is not nil.
+// It blocks until ctx is done.
+func (w *QuoteWatchdog) Stream(ctx context.Context, callback func(*Quote)) {
+	opts := w.client.SubscribeOptions
+	opts.Restart = w.restart
+	Subscribe(ctx, w.client, "/OnQuote", quoteDecoder, func(q Quote) {
+		w.Observe(&q)
+		if callback != nil {
+			callback(&q)
+		}
+	}, opts)
+}
+
+// Observe records a quote; use it as the SocketOnQuote callback
+func (w *QuoteWatchdog) Observe(quote *Quote) {
+	tick := time.Now()
+	if quote.TimestampUTC > 0 {
+		tick = time.UnixMilli(quote.TimestampUTC)
+	}
+
+	w.mu.Lock()
+	state, ok := w.symbols[quote.Symbol]
+	if !ok {
+		state = &quoteFreshness{}
+		w.symbols[quote.Symbol] = state
+	}
+	if tick.After(state.lastTick) {
+		state.lastTick = tick
+	}
+	recovered := state.stale
+	state.stale = false
+	w.mu.Unlock()
+
+	if recovered {
+		w.emit(QuoteWatchdogEvent{
+			Symbol:   quote.Symbol,
+			Kind:     QuoteRecovered,
+			LastTick: tick,
+			Age:      time.Since(tick),
+		})
+	}
+}
+
+// Run checks quote freshness until ctx is done. Symbols subscribed through
+// the client are watched even before their first quote arrives.
+func (w *QuoteWatchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check reports symbols whose last quote exceeds their maximum age
+func (w *QuoteWatchdog) check(ctx context.Context) {
+	subscribed := w.client.Subscriptions().Quotes
+	now := time.Now()
+
+	w.mu.Lock()
+	for symbol := range subscribed {
+		state, ok := w.symbols[symbol]
+		if !ok {
+			state = &quoteFreshness{}
+			w.symbols[symbol] = state
+		}
+		state.subscribed = true
+	}
+	var candidates []QuoteWatchdogEvent
+	for symbol, state := range w.symbols {
+		since := state.lastTick
+		if since.IsZero() {
+			since = w.started
+		}
+		age := now.Sub(since)
+
+		// Forget unsubscribed symbols once their quotes stop
+		if _, ok := subscribed[symbol]; state.subscribed && !ok && (state.stale || age > w.maxAge(symbol)) {
+			delete(w.symbols, symbol)
+			continue
+		}
+		if state.stale {
+			continue
+		}
+		if age > w.maxAge(symbol) {
+			candidates = append(candidates, QuoteWatchdogEvent{
+				Symbol:   symbol,
+				Kind:     QuoteStale,
+				LastTick: state.lastTick,
+				Age:      age,
+			})
+		}
+	}
+	w.mu.Unlock()
+
+	reconnect := false
+	for _, event := range candidates {
+		if w.config.CheckSession && !w.sessionOpen(ctx, event.Symbol) {
+			continue
+		}
+
+		w.mu.Lock()
+		state, ok := w.symbols[event.Symbol]
+		// A quote may have arrived while checking the session
+		fresh := !ok || state.stale || state.lastTick.After(event.LastTick)
+		if ok {
+			state.stale = !fresh
+		}
+		w.mu.Unlock()
+		if fresh {
+			continue
+		}
+
+		w.emit(event)
+		if interval, ok := subscribed[event.Symbol]; ok && w.config.Resubscribe {
+			w.client.Subscribe(ctx, event.Symbol, interval)
+		}
+		reconnect = reconnect || w.config.Reconnect
+	}
+
+	if reconnect {
+		select {
+		case w.restart <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// maxAge returns the maximum quote age of a symbol
+func (w *QuoteWatchdog) maxAge(symbol string) time.Duration {
+	if maxAge, ok := w.config.SymbolMaxAge[symbol]; ok {
+		return maxAge
+	}
+	return w.config.MaxAge
+}
+
+// sessionOpen reports whether the symbol trade session is open, treating
+// lookup failures as open
+func (w *QuoteWatchdog) sessionOpen(ctx context.Context, symbol string) bool {
+	w.mu.Lock()
+	cached, ok := w.sessions[symbol]
+	w.mu.Unlock()
+	if ok && time.Since(cached.checked) < w.config.SessionCacheTTL {
+		return cached.open
+	}
+
+	open, err := w.client.IsTradeSession(ctx, symbol)
+	if err != nil {
+		return true
+	}
+
+	w.mu.Lock()
+	w.sessions[symbol] = sessionCheck{open: open, checked: time.Now()}
+	w.mu.Unlock()
+	return open
+}
+
+// emit sends an event without blocking
+func (w *QuoteWatchdog) emit(event QuoteWatchdogEvent) {
+	select {
+	case w.events <- event:
+	default:
+	}
+}
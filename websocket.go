@@ -2,9 +2,9 @@ package mt5api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
+	"log/slog"
 	"net/url"
 	"strings"
 	"time"
@@ -26,7 +26,7 @@ func (c *Client) ConnectWebSocket(ctx context.Context, endpoint string) (*WebSoc
 		Scheme:   "ws",
 		Host:     strings.TrimPrefix(wsURL, "ws://"),
 		Path:     endpoint,
-		RawQuery: fmt.Sprintf("id=%s", c.Token),
+		RawQuery: url.Values{"id": {c.Token()}}.Encode(),
 	}
 
 	// Handle wss scheme
@@ -37,9 +37,21 @@ func (c *Client) ConnectWebSocket(ctx context.Context, endpoint string) (*WebSoc
 
 	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
 	if err != nil {
+		if c.Logger != nil {
+			c.Logger.LogAttrs(ctx, c.ErrorLogLevel, "mt5api websocket dial failed",
+				slog.String("url", redactURL(u.String())),
+				slog.String("error", err.Error()),
+			)
+		}
 		return nil, fmt.Errorf("websocket dial error: %w", err)
 	}
 
+	if c.Logger != nil {
+		c.Logger.LogAttrs(ctx, c.LogLevel, "mt5api websocket connected",
+			slog.String("url", redactURL(u.String())),
+		)
+	}
+
 	return &WebSocketConnection{
 		conn:     conn,
 		endpoint: endpoint,
@@ -123,375 +135,62 @@ func (c *Client) OnMail(ctx context.Context) (*WebSocketConnection, error) {
 	return c.ConnectWebSocket(ctx, "/OnMail")
 }
 
+// Decoders for the typed WebSocket streams
+var (
+	quoteDecoder       = EnvelopeDecoder("Quote", func(q Quote) bool { return q.Symbol != "" })
+	orderUpdateDecoder = EnvelopeDecoder[OrderUpdateSummary]("OrderUpdate", nil)
+	orderProfitDecoder = EnvelopeDecoder("ProfitUpdate", func(ProfitUpdate) bool { return true })
+	ohlcDecoder        = EnvelopeDecoder("Ohlc", func(o OhlcSubscription) bool { return o.Symbol != "" })
+	marketWatchDecoder = EnvelopeDecoder("MarketWatch", func(m MarketWatch) bool { return m.Symbol != "" })
+	orderBookDecoder   = EnvelopeDecoder("OrderBook", func(d DepthOfMarket) bool { return d.Symbol != "" })
+	tickHistoryDecoder = EnvelopeDecoder("TickHistory", func(t TickHistoryEventArgs) bool { return t.Symbol != "" })
+	tickValueDecoder   = EnvelopeDecoder("TickValue", func(t SymbolTickValue) bool { return t.Symbol != "" })
+	mailDecoder        = EnvelopeDecoder("Mail", func(m MailMessage) bool { return m.Id != 0 })
+)
+
+// SocketOnQuote streams quotes to callback, reconnecting until ctx is done
 func (c *Client) SocketOnQuote(ctx context.Context, callback func(*Quote)) {
-	backoff := time.Second
-	maxBackoff := 5 * time.Minute
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wsConn, err := c.ConnectWebSocket(ctx, "/OnQuote")
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-					}
-					continue
-				}
-			}
-
-			backoff = time.Second
-			done := make(chan struct{})
-			go func() {
-				defer close(done)
-				for {
-					_, rawMessage, err := wsConn.conn.ReadMessage()
-					if err != nil {
-						return
-					}
-
-					var quote Quote
-					if err := json.Unmarshal(rawMessage, &quote); err == nil && quote.Symbol != "" {
-						callback(&quote)
-						continue
-					}
-
-					var response struct {
-						Type string          `json:"type"`
-						Data json.RawMessage `json:"data"`
-					}
-					if err := json.Unmarshal(rawMessage, &response); err == nil {
-						switch response.Type {
-						case "Quote":
-							if err := json.Unmarshal(response.Data, &quote); err == nil {
-								callback(&quote)
-							}
-						}
-					}
-				}
-			}()
-
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if err := wsConn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-						return
-					}
-				case <-done:
-					goto reconnect
-				}
-			}
-		reconnect:
-			time.Sleep(time.Second)
-		}
-	}
+	Subscribe(ctx, c, "/OnQuote", quoteDecoder, func(q Quote) { callback(&q) }, c.SubscribeOptions)
 }
 
+// SocketOnOrderUpdate streams order updates to callback, reconnecting until ctx is done
 func (c *Client) SocketOnOrderUpdate(ctx context.Context, callback func(*OrderUpdateSummary)) {
-	backoff := time.Second
-	maxBackoff := 5 * time.Minute
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wsConn, err := c.ConnectWebSocket(ctx, "/OnOrderUpdate")
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-					}
-					continue
-				}
-			}
-
-			backoff = time.Second
-			done := make(chan struct{})
-			go func() {
-				defer close(done)
-				for {
-					_, rawMessage, err := wsConn.conn.ReadMessage()
-					if err != nil {
-						return
-					}
-
-					var response struct {
-						Type string          `json:"type"`
-						Data json.RawMessage `json:"data"`
-					}
-					if err := json.Unmarshal(rawMessage, &response); err == nil {
-						switch response.Type {
-						case "OrderUpdate":
-							var orderUpdateSummary OrderUpdateSummary
-							if err := json.Unmarshal(response.Data, &orderUpdateSummary); err == nil {
-								callback(&orderUpdateSummary)
-							}
-						}
-					}
-				}
-			}()
-
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if err := wsConn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-						return
-					}
-				case <-done:
-					goto reconnect
-				}
-			}
-		reconnect:
-			time.Sleep(time.Second)
-		}
-	}
+	Subscribe(ctx, c, "/OnOrderUpdate", orderUpdateDecoder, func(u OrderUpdateSummary) { callback(&u) }, c.SubscribeOptions)
 }
 
+// SocketOnOrderProfit streams profit updates to callback, reconnecting until ctx is done
 func (c *Client) SocketOnOrderProfit(ctx context.Context, callback func(*ProfitUpdate)) {
-	backoff := time.Second
-	maxBackoff := 5 * time.Minute
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wsConn, err := c.ConnectWebSocket(ctx, "/OnOrderProfit")
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-					}
-					continue
-				}
-			}
-
-			backoff = time.Second
-			done := make(chan struct{})
-			go func() {
-				defer close(done)
-				for {
-					_, rawMessage, err := wsConn.conn.ReadMessage()
-					if err != nil {
-						return
-					}
-
-					var profitUpdate ProfitUpdate
-					if err := json.Unmarshal(rawMessage, &profitUpdate); err == nil {
-						callback(&profitUpdate)
-						continue
-					}
-
-					var response struct {
-						Type string          `json:"type"`
-						Data json.RawMessage `json:"data"`
-					}
-					if err := json.Unmarshal(rawMessage, &response); err == nil {
-						switch response.Type {
-						case "ProfitUpdate":
-							if err := json.Unmarshal(response.Data, &profitUpdate); err == nil {
-								callback(&profitUpdate)
-							}
-						}
-					}
-				}
-			}()
-
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if err := wsConn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-						return
-					}
-				case <-done:
-					goto reconnect
-				}
-			}
-		reconnect:
-			time.Sleep(time.Second)
-		}
-	}
+	Subscribe(ctx, c, "/OnOrderProfit", orderProfitDecoder, func(p ProfitUpdate) { callback(&p) }, c.SubscribeOptions)
 }
 
+// SocketOnOHLC streams OHLC bars to callback, reconnecting until ctx is done
 func (c *Client) SocketOnOHLC(ctx context.Context, callback func(*OhlcSubscription)) {
-	backoff := time.Second
-	maxBackoff := 5 * time.Minute
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wsConn, err := c.ConnectWebSocket(ctx, "/OnOhlc")
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-					}
-					continue
-				}
-			}
-
-			backoff = time.Second
-
-			done := make(chan struct{})
-			go func() {
-				defer close(done)
-				for {
-					_, rawMessage, err := wsConn.conn.ReadMessage()
-					if err != nil {
-						return
-					}
-
-					var ohlc OhlcSubscription
-					if err := json.Unmarshal(rawMessage, &ohlc); err == nil {
-						callback(&ohlc)
-						continue
-					}
-
-					var response struct {
-						Type string          `json:"type"`
-						Data json.RawMessage `json:"data"`
-					}
-					if err := json.Unmarshal(rawMessage, &response); err == nil {
-						switch response.Type {
-						case "Ohlc":
-							if err := json.Unmarshal(response.Data, &ohlc); err == nil {
-								callback(&ohlc)
-							}
-						}
-					}
-				}
-			}()
-
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if err := wsConn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-						return
-					}
-				case <-done:
-					goto reconnect
-				}
-			}
-		reconnect:
-			time.Sleep(time.Second)
-		}
-	}
+	Subscribe(ctx, c, "/OnOhlc", ohlcDecoder, func(o OhlcSubscription) { callback(&o) }, c.SubscribeOptions)
 }
 
+// SocketOnMarketWatch streams market watch data to callback, reconnecting until ctx is done
 func (c *Client) SocketOnMarketWatch(ctx context.Context, callback func(MarketWatch)) {
-	backoff := time.Second
-	maxBackoff := 5 * time.Minute
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wsConn, err := c.ConnectWebSocket(ctx, "/OnMarketWatch")
-			if err != nil {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-					}
-					continue
-				}
-			}
-
-			backoff = time.Second
-			done := make(chan struct{})
-			go func() {
-				defer close(done)
-				for {
-					_, rawMessage, err := wsConn.conn.ReadMessage()
-					if err != nil {
-						return
-					}
-
-					var marketWatch MarketWatch
-					if err := json.Unmarshal(rawMessage, &marketWatch); err == nil {
-						callback(marketWatch)
-						continue
-					}
-
-					var response struct {
-						Type string          `json:"type"`
-						Data json.RawMessage `json:"data"`
-					}
-					if err := json.Unmarshal(rawMessage, &response); err == nil {
-						switch response.Type {
-						case "MarketWatch":
-							if err := json.Unmarshal(response.Data, &marketWatch); err == nil {
-								callback(marketWatch)
-							}
-						}
-					}
-				}
-			}()
-
-			ticker := time.NewTicker(30 * time.Second)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					if err := wsConn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-						return
-					}
-				case <-done:
-					goto reconnect
-				}
-			}
-		reconnect:
-			time.Sleep(time.Second)
-		}
-	}
+	Subscribe(ctx, c, "/OnMarketWatch", marketWatchDecoder, callback, c.SubscribeOptions)
+}
+
+// SocketOnOrderBook streams order book snapshots to callback, reconnecting until ctx is done
+func (c *Client) SocketOnOrderBook(ctx context.Context, callback func(*DepthOfMarket)) {
+	Subscribe(ctx, c, "/OnOrderBook", orderBookDecoder, func(d DepthOfMarket) { callback(&d) }, c.SubscribeOptions)
+}
+
+// SocketOnTickHistory streams tick history to callback, reconnecting until ctx is done
+func (c *Client) SocketOnTickHistory(ctx context.Context, callback func(*TickHistoryEventArgs)) {
+	Subscribe(ctx, c, "/OnTickHistory", tickHistoryDecoder, func(t TickHistoryEventArgs) { callback(&t) }, c.SubscribeOptions)
+}
+
+// SocketOnTickValue streams tick value updates to callback, reconnecting until ctx is done
+func (c *Client) SocketOnTickValue(ctx context.Context, callback func(*SymbolTickValue)) {
+	Subscribe(ctx, c, "/OnTickValue", tickValueDecoder, func(t SymbolTickValue) { callback(&t) }, c.SubscribeOptions)
+}
+
+// SocketOnMail streams mail messages to callback, reconnecting until ctx is done
+func (c *Client) SocketOnMail(ctx context.Context, callback func(*MailMessage)) {
+	Subscribe(ctx, c, "/OnMail", mailDecoder, func(m MailMessage) { callback(&m) }, c.SubscribeOptions)
 }
 
 // OrderUpdateSummary represents order update summary
@@ -593,6 +292,19 @@ type MarketWatch struct {
 	Volume      int64   `json:"volume"`
 }
 
+// BookLevel represents a price level of the order book
+type BookLevel struct {
+	Price  float64 `json:"price"`
+	Volume float64 `json:"volume"`
+}
+
+// DepthOfMarket represents order book (DOM) data
+type DepthOfMarket struct {
+	Symbol string      `json:"symbol"`
+	Bids   []BookLevel `json:"bids"`
+	Asks   []BookLevel `json:"asks"`
+}
+
 // SymbolTickValue represents tick value update
 type SymbolTickValue struct {
 	Symbol    string  `json:"symbol"`